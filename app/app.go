@@ -1,6 +1,7 @@
 package app
 
 import (
+	"inventory-management-api/config"
 	"inventory-management-api/model/web"
 	"log"
 	"net/http"
@@ -10,6 +11,9 @@ import (
 
 func NewApp() *fiber.App {
 	app := fiber.New(fiber.Config{
+		// Batas body mengikuti batas ukuran lampiran, ditambah ruang untuk field multipart lain
+		BodyLimit: int(config.MaxUploadSize()) + 1<<20,
+
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			statusCode := fiber.StatusInternalServerError
 			message := "Internal Server Error"
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"log"
+	"os"
+)
+
+// CostingMethod membaca metode penilaian persediaan dari env COSTING_METHOD: "fifo" atau
+// "average" (moving weighted average). Default "fifo".
+func CostingMethod() string {
+	const defaultMethod = "fifo"
+
+	value := os.Getenv("COSTING_METHOD")
+	switch value {
+	case "":
+		return defaultMethod
+	case "fifo", "average":
+		return value
+	}
+
+	log.Printf("[WARNING] COSTING_METHOD tidak valid (%q), memakai default %s", value, defaultMethod)
+	return defaultMethod
+}
@@ -31,8 +31,9 @@ func NewGormMySQLConnection() (*gorm.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
 		dbUser, dbPass, dbHost, dbPort, dbName)
 
-	// Open connection with GORM
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	// Open connection with GORM. TranslateError mengubah error unique index menjadi
+	// gorm.ErrDuplicatedKey sehingga service bisa membedakannya dari error lain.
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// IdempotencyKeyTTL membaca masa berlaku Idempotency-Key dari env IDEMPOTENCY_KEY_TTL
+// (format durasi Go, contoh "24h" atau "30m"). Default 24 jam.
+func IdempotencyKeyTTL() time.Duration {
+	const defaultTTL = 24 * time.Hour
+
+	value := os.Getenv("IDEMPOTENCY_KEY_TTL")
+	if value == "" {
+		return defaultTTL
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Printf("[WARNING] IDEMPOTENCY_KEY_TTL tidak valid (%q), memakai default %s", value, defaultTTL)
+		return defaultTTL
+	}
+	return ttl
+}
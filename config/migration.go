@@ -0,0 +1,82 @@
+package config
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+)
+
+// AutoMigrate menyesuaikan skema tabel dengan model domain
+func AutoMigrate(db *gorm.DB) error {
+	err := db.AutoMigrate(
+		&domain.User{},
+		&domain.Category{},
+		&domain.Product{},
+		&domain.Warehouse{},
+		&domain.ProductStock{},
+		&domain.StockDocument{},
+		&domain.StockMovement{},
+		&domain.StockLot{},
+		&domain.StockMovementLot{},
+		&domain.SerialNumber{},
+		&domain.StockMovementSerial{},
+		&domain.Stocktake{},
+		&domain.StocktakeLine{},
+		&domain.IdempotencyKey{},
+		&domain.Reservation{},
+		&domain.PurchaseOrder{},
+		&domain.PurchaseOrderLine{},
+		&domain.SalesOrder{},
+		&domain.SalesOrderLine{},
+		&domain.Supplier{},
+		&domain.ProductSupplier{},
+		&domain.StockAlert{},
+		&domain.CostLayer{},
+		&domain.StockSnapshot{},
+		&domain.Unit{},
+		&domain.ProductUnit{},
+		&domain.ProductAttribute{},
+		&domain.ProductBarcode{},
+		&domain.CategoryDeletion{},
+		&domain.KitComponent{},
+		&domain.KitAssembly{},
+		&domain.ProductAttachment{},
+	)
+	if err != nil {
+		return err
+	}
+
+	return migrateDefaultWarehouse(db)
+}
+
+// migrateDefaultWarehouse memindahkan data lama (sebelum ada multi gudang) ke satu gudang
+// default: saldo Product.Stock menjadi saldo gudang tersebut dan movement lama diberi
+// warehouse_id-nya. Hanya berjalan sekali, yaitu saat belum ada gudang sama sekali.
+func migrateDefaultWarehouse(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&domain.Warehouse{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		warehouse := domain.Warehouse{Name: "Gudang Utama"}
+		if err := tx.Create(&warehouse).Error; err != nil {
+			return err
+		}
+
+		err := tx.Exec(
+			"INSERT INTO product_stocks (product_id, warehouse_id, stock) SELECT id, ?, stock FROM products",
+			warehouse.ID,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.StockMovement{}).
+			Where("warehouse_id IS NULL OR warehouse_id = 0").
+			Update("warehouse_id", warehouse.ID).Error
+	})
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"inventory-management-api/storage"
+	"log"
+	"os"
+	"strconv"
+)
+
+// NewStorage membuat penyimpanan lampiran produk. Saat ini tersedia penyimpanan lokal di
+// folder env STORAGE_DIR (default "uploads").
+func NewStorage() storage.Storage {
+	dir := os.Getenv("STORAGE_DIR")
+	if dir == "" {
+		dir = "uploads"
+	}
+	return storage.NewLocalStorage(dir)
+}
+
+// MaxUploadSize membaca batas ukuran satu berkas lampiran dari env UPLOAD_MAX_SIZE_MB (dalam
+// megabyte). Default 10 MB.
+func MaxUploadSize() int64 {
+	const defaultSize = 10
+
+	value := os.Getenv("UPLOAD_MAX_SIZE_MB")
+	if value == "" {
+		return defaultSize << 20
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		log.Printf("[WARNING] UPLOAD_MAX_SIZE_MB tidak valid (%q), memakai default %d MB", value, defaultSize)
+		return defaultSize << 20
+	}
+	return int64(size) << 20
+}
@@ -5,6 +5,7 @@ import (
 	"inventory-management-api/service"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -43,6 +44,32 @@ func (c *CategoryController) FindAll(ctx *fiber.Ctx) error {
 	})
 }
 
+// FindTree godoc
+// @Summary Mendapatkan pohon kategori
+// @Description Mengambil seluruh kategori dalam bentuk pohon (department > group > subgroup)
+// @Tags Categories
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} web.WebResponse{data=[]web.CategoryTreeResponse}
+// @Failure 500 {object} web.WebResponse
+// @Router /categories/tree [get]
+func (c *CategoryController) FindTree(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindTree()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
 // FindById godoc
 // @Summary Mendapatkan kategori berdasarkan ID
 // @Description Mengambil detail kategori berdasarkan ID yang diberikan
@@ -119,7 +146,7 @@ func (c *CategoryController) Create(ctx *fiber.Ctx) error {
 
 // Update godoc
 // @Summary Memperbarui kategori
-// @Description Mengubah data kategori berdasarkan ID
+// @Description Mengubah data kategori berdasarkan ID. Kategori tidak bisa dipindah ke bawah dirinya sendiri atau subkategorinya.
 // @Tags Categories
 // @Accept json
 // @Produce json
@@ -166,14 +193,17 @@ func (c *CategoryController) Update(ctx *fiber.Ctx) error {
 
 // Delete godoc
 // @Summary Menghapus kategori
-// @Description Menghapus kategori berdasarkan ID
+// @Description Menghapus kategori berdasarkan ID. Mode refuse (default) menolak dengan 409 beserta jumlah produk dan subkategori jika kategori tidak kosong; reassign memindahkan produk dan subkategori ke target_id; cascade menghapus produknya (soft delete) dan ditolak dengan 409 jika kategori masih punya subkategori, atau jika ada produk yang masih dipakai sebagai komponen kit atau punya varian di luar kategori ini. Kategori dipindahkan ke trash (soft delete) dan mode yang dipilih dicatat.
 // @Tags Categories
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "ID Kategori"
-// @Success 200 {object} web.WebResponse{data=string}
+// @Param mode query string false "refuse (default), reassign, atau cascade"
+// @Param target_id query int false "Kategori tujuan, wajib untuk mode reassign"
+// @Success 200 {object} web.WebResponse{data=web.CategoryDeleteResponse}
 // @Failure 400 {object} web.WebResponse
 // @Failure 404 {object} web.WebResponse
+// @Failure 409 {object} web.WebResponse{data=web.CategoryDeleteResponse}
 // @Router /categories/{id} [delete]
 func (c *CategoryController) Delete(ctx *fiber.Ctx) error {
 	id, err := strconv.Atoi(ctx.Params("id"))
@@ -185,18 +215,196 @@ func (c *CategoryController) Delete(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err = c.Service.Delete(id)
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	req := web.CategoryDeleteRequest{Mode: ctx.Query("mode")}
+	if targetID := ctx.Query("target_id"); targetID != "" {
+		req.TargetID, err = strconv.Atoi(targetID)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid target_id",
+			})
+		}
+	}
+
+	result, err := c.Service.Delete(id, userID, req)
 	if err != nil {
-		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Error:  "Category not found",
+		switch err.Error() {
+		case "category not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Category not found",
+			})
+		case "category is still in use", "category has subcategories", "product has variants", "product is used as kit component":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Data:   result,
+				Error:  err.Error(),
+			})
+		case "target category not found", "target category cannot be the deleted category or its subcategory":
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		default:
+			if strings.HasPrefix(err.Error(), "validation error:") {
+				return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+					Code:   http.StatusBadRequest,
+					Status: "BAD REQUEST",
+					Error:  err.Error(),
+				})
+			}
+			return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+				Code:   http.StatusInternalServerError,
+				Status: "INTERNAL SERVER ERROR",
+				Error:  err.Error(),
+			})
+		}
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindTrashed godoc
+// @Summary Daftar kategori di trash
+// @Description Endpoint ini digunakan untuk mengambil kategori yang sudah dihapus (soft delete) dan masih bisa dipulihkan.
+// @Tags Categories
+// @Produce json
+// @Success 200 {object} web.WebResponse{data=[]web.CategoryResponse}
+// @Failure 500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /categories/trash [get]
+func (c *CategoryController) FindTrashed(ctx *fiber.Ctx) error {
+	categories, err := c.Service.FindTrashed()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
 		})
 	}
 
 	return ctx.JSON(web.WebResponse{
 		Code:   http.StatusOK,
 		Status: "OK",
-		Data:   "Category deleted",
+		Data:   categories,
+	})
+}
+
+// Restore godoc
+// @Summary Pulihkan kategori dari trash
+// @Description Endpoint ini digunakan untuk memulihkan kategori yang sudah dihapus. Kategori induknya harus aktif; produk yang ikut terhapus dipulihkan lewat trash produk.
+// @Tags Categories
+// @Produce json
+// @Param id path int true "ID Kategori"
+// @Success 200 {object} web.WebResponse{data=web.CategoryResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /categories/trash/{id}/restore [post]
+func (c *CategoryController) Restore(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid category ID",
+		})
+	}
+
+	category, err := c.Service.Restore(id)
+	if err != nil {
+		switch err.Error() {
+		case "category not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Category not found in trash",
+			})
+		case "parent category is deleted":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   category,
+	})
+}
+
+// Purge godoc
+// @Summary Hapus permanen kategori dari trash
+// @Description Endpoint ini digunakan untuk menghapus permanen kategori di trash. Ditolak (409) selama kategori masih dirujuk produk (termasuk di trash), subkategori, atau stocktake; jumlah rujukannya dikirim di data.
+// @Tags Categories
+// @Produce json
+// @Param id path int true "ID Kategori"
+// @Success 200 {object} web.WebResponse{data=web.PurgeResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Failure 409 {object} web.WebResponse{data=web.PurgeResponse}
+// @Security BearerAuth
+// @Router /categories/trash/{id} [delete]
+func (c *CategoryController) Purge(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid category ID",
+		})
+	}
+
+	result, err := c.Service.Purge(id)
+	if err != nil {
+		switch err.Error() {
+		case "category not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Category not found in trash",
+			})
+		case "category is still referenced":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Data:   result,
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
 	})
 }
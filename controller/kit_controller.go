@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// kitBadRequests berisi pesan error pengaturan bill of materials yang disebabkan input user
+// (dibalas 400). Error perakitan memakai stockMovementBadRequests.
+var kitBadRequests = map[string]bool{
+	"kit cannot contain itself":                    true,
+	"duplicate kit component":                      true,
+	"component product not found":                  true,
+	"serialized product cannot be a kit":           true,
+	"serialized product cannot be a kit component": true,
+	"parent product cannot be a kit":               true,
+	"parent product cannot be a kit component":     true,
+	"kit component cannot be a kit":                true,
+}
+
+type KitController struct {
+	Service service.KitService
+}
+
+func NewKitController(service service.KitService) *KitController {
+	return &KitController{Service: service}
+}
+
+// FindKit godoc
+// @Summary Bill of materials dan ketersediaan kit
+// @Description Mengambil komponen kit beserta ketersediaannya. Assembled adalah stok kit yang sudah dirakit, buildable jumlah kit yang masih bisa dirakit dari stok komponen, dan available = assembled - reserved + buildable. Tanpa warehouse_id dihitung dari stok seluruh gudang.
+// @Tags Kits
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk kit"
+// @Param warehouse_id query int false "Hitung ketersediaan di gudang tertentu"
+// @Success 200 {object} web.WebResponse{data=web.KitResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/components [get]
+func (c *KitController) FindKit(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	warehouseID := 0
+	if v := ctx.Query("warehouse_id"); v != "" {
+		warehouseID, err = strconv.Atoi(v)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid warehouse_id",
+			})
+		}
+	}
+
+	result, err := c.Service.FindKit(id, warehouseID)
+	if err != nil {
+		return kitErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// SetComponents godoc
+// @Summary Mengatur bill of materials kit
+// @Description Mengganti seluruh komponen kit beserta quantity per unit kit. Daftar kosong berarti produk tidak lagi menjadi kit. Kit dan komponennya tidak boleh produk serialized atau produk induk varian, dan komponen tidak boleh berupa kit.
+// @Tags Kits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk kit"
+// @Param request body web.KitComponentsRequest true "Komponen kit"
+// @Success 200 {object} web.WebResponse{data=web.KitResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/components [put]
+func (c *KitController) SetComponents(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	var req web.KitComponentsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.SetComponents(id, req)
+	if err != nil {
+		return kitErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Assemble godoc
+// @Summary Merakit kit dari komponennya
+// @Description Mengubah stok komponen menjadi stok kit di satu gudang: setiap komponen diposting sebagai movement out dan kit sebagai movement in dalam satu transaksi, dengan reference kit-assembly:{id}. Harga pokok kit adalah total harga pokok komponen.
+// @Tags Kits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk kit"
+// @Param Idempotency-Key header string false "Key unik per transaksi; retry dengan key yang sama membalas response pertama tanpa memposting ulang"
+// @Param request body web.KitAssembleRequest true "Gudang dan jumlah kit"
+// @Success 201 {object} web.WebResponse{data=web.KitAssemblyResponse}
+// @Failure 400,401,404,500 {object} web.WebResponse
+// @Router /products/{id}/assemble [post]
+func (c *KitController) Assemble(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	var req web.KitAssembleRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Assemble(userID, id, req)
+	if err != nil {
+		return kitErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+func kitErrorResponse(ctx *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case msg == "product not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  msg,
+		})
+	case kitBadRequests[msg], stockMovementBadRequests[msg], strings.HasPrefix(msg, "validation error:"):
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  msg,
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  msg,
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductAttachmentController struct {
+	Service service.ProductAttachmentService
+}
+
+func NewProductAttachmentController(service service.ProductAttachmentService) *ProductAttachmentController {
+	return &ProductAttachmentController{Service: service}
+}
+
+// FindByProduct godoc
+// @Summary Daftar lampiran produk
+// @Description Mengambil gambar dan dokumen sebuah produk beserta URL-nya
+// @Tags Product
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Success 200 {object} web.WebResponse{data=[]web.ProductAttachmentResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/attachments [get]
+func (c *ProductAttachmentController) FindByProduct(ctx *fiber.Ctx) error {
+	productID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	result, err := c.Service.FindByProduct(productID)
+	if err != nil {
+		return productAttachmentErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// UploadImage godoc
+// @Summary Mengunggah gambar produk
+// @Description Mengunggah gambar produk (JPEG, PNG, atau GIF) lewat multipart field "file". Jenis berkas ditentukan dari isinya, dan server membuat thumbnail JPEG. Ukuran maksimum diatur env UPLOAD_MAX_SIZE_MB.
+// @Tags Product
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param file formData file true "Berkas gambar"
+// @Success 201 {object} web.WebResponse{data=web.ProductAttachmentResponse}
+// @Failure 400,401,404,413,500 {object} web.WebResponse
+// @Router /products/{id}/images [post]
+func (c *ProductAttachmentController) UploadImage(ctx *fiber.Ctx) error {
+	return c.upload(ctx, "image")
+}
+
+// UploadDocument godoc
+// @Summary Mengunggah dokumen produk
+// @Description Mengunggah dokumen produk seperti datasheet atau MSDS (PDF) lewat multipart field "file". Jenis berkas ditentukan dari isinya. Ukuran maksimum diatur env UPLOAD_MAX_SIZE_MB.
+// @Tags Product
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param file formData file true "Berkas dokumen"
+// @Success 201 {object} web.WebResponse{data=web.ProductAttachmentResponse}
+// @Failure 400,401,404,413,500 {object} web.WebResponse
+// @Router /products/{id}/documents [post]
+func (c *ProductAttachmentController) UploadDocument(ctx *fiber.Ctx) error {
+	return c.upload(ctx, "document")
+}
+
+// Download godoc
+// @Summary Mengunduh berkas lampiran produk
+// @Description Mengirim isi berkas gambar atau dokumen produk. Berkas hanya bisa diunduh oleh user yang login.
+// @Tags Product
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param attachment_id path int true "ID Lampiran"
+// @Success 200 {file} file
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/attachments/{attachment_id}/file [get]
+func (c *ProductAttachmentController) Download(ctx *fiber.Ctx) error {
+	return c.send(ctx, false)
+}
+
+// Thumbnail godoc
+// @Summary Mengunduh thumbnail gambar produk
+// @Description Mengirim thumbnail JPEG dari lampiran gambar. Dokumen tidak punya thumbnail.
+// @Tags Product
+// @Produce jpeg
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param attachment_id path int true "ID Lampiran"
+// @Success 200 {file} file
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/attachments/{attachment_id}/thumbnail [get]
+func (c *ProductAttachmentController) Thumbnail(ctx *fiber.Ctx) error {
+	return c.send(ctx, true)
+}
+
+// Delete godoc
+// @Summary Menghapus lampiran produk
+// @Description Menghapus gambar atau dokumen produk beserta berkasnya di storage
+// @Tags Product
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param attachment_id path int true "ID Lampiran"
+// @Success 200 {object} web.WebResponse{data=string}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/attachments/{attachment_id} [delete]
+func (c *ProductAttachmentController) Delete(ctx *fiber.Ctx) error {
+	productID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+	attachmentID, err := strconv.Atoi(ctx.Params("attachment_id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid attachment ID",
+		})
+	}
+
+	if err := c.Service.Delete(productID, attachmentID); err != nil {
+		return productAttachmentErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "Attachment deleted",
+	})
+}
+
+func (c *ProductAttachmentController) send(ctx *fiber.Ctx, thumbnail bool) error {
+	productID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+	attachmentID, err := strconv.Atoi(ctx.Params("attachment_id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid attachment ID",
+		})
+	}
+
+	attachment, file, err := c.Service.Open(productID, attachmentID, thumbnail)
+	if err != nil {
+		return productAttachmentErrorResponse(ctx, err)
+	}
+
+	ctx.Set(fiber.HeaderContentType, attachment.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}))
+	ctx.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	// Berkas ditutup oleh fasthttp setelah response terkirim
+	return ctx.SendStream(file)
+}
+
+func (c *ProductAttachmentController) upload(ctx *fiber.Ctx, kind string) error {
+	productID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "file is required",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Upload(productID, userID, kind, file)
+	if err != nil {
+		return productAttachmentErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+func productAttachmentErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "product not found", "attachment not found", "attachment file not found", "attachment has no thumbnail":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  err.Error(),
+		})
+	case "file too large":
+		return ctx.Status(http.StatusRequestEntityTooLarge).JSON(web.WebResponse{
+			Code:   http.StatusRequestEntityTooLarge,
+			Status: "REQUEST ENTITY TOO LARGE",
+			Error:  err.Error(),
+		})
+	case "file is empty", "unsupported file type", "invalid image", "image dimensions too large":
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductBarcodeController struct {
+	Service service.ProductBarcodeService
+}
+
+func NewProductBarcodeController(service service.ProductBarcodeService) *ProductBarcodeController {
+	return &ProductBarcodeController{Service: service}
+}
+
+// FindByProduct godoc
+// @Summary Mendapatkan barcode sebuah produk
+// @Description Mengambil semua barcode yang terdaftar pada produk
+// @Tags Product
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Success 200 {object} web.WebResponse{data=[]web.ProductBarcodeResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/barcodes [get]
+func (c *ProductBarcodeController) FindByProduct(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	result, err := c.Service.FindByProduct(id)
+	if err != nil {
+		return productBarcodeErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Menambahkan barcode ke produk
+// @Description Menambahkan barcode EAN-13, UPC-A, atau Code128. Check digit EAN-13 dan UPC-A divalidasi. Barcode tidak boleh sama dengan SKU atau barcode produk lain.
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param request body web.ProductBarcodeCreateRequest true "Data barcode"
+// @Success 201 {object} web.WebResponse{data=web.ProductBarcodeResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Router /products/{id}/barcodes [post]
+func (c *ProductBarcodeController) Create(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	var req web.ProductBarcodeCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Create(id, req)
+	if err != nil {
+		return productBarcodeErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// Delete godoc
+// @Summary Menghapus barcode produk
+// @Description Menghapus satu barcode dari produk
+// @Tags Product
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param barcode_id path int true "ID Barcode"
+// @Success 200 {object} web.WebResponse{data=string}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/barcodes/{barcode_id} [delete]
+func (c *ProductBarcodeController) Delete(ctx *fiber.Ctx) error {
+	productID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+	barcodeID, err := strconv.Atoi(ctx.Params("barcode_id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid barcode ID",
+		})
+	}
+
+	if err := c.Service.Delete(productID, barcodeID); err != nil {
+		return productBarcodeErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "Barcode deleted",
+	})
+}
+
+func productBarcodeErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "product not found", "barcode not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  err.Error(),
+		})
+	case "barcode already in use":
+		return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+			Code:   http.StatusConflict,
+			Status: "CONFLICT",
+			Error:  err.Error(),
+		})
+	default:
+		if strings.HasPrefix(err.Error(), "validation error:") || strings.HasPrefix(err.Error(), "invalid barcode:") {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"inventory-management-api/service"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -19,15 +20,74 @@ func NewProductController(service service.ProductService) *ProductController {
 
 // FindAll godoc
 // @Summary Mendapatkan Seluruh Produk
-// @Description Mengambil seluruh data produk pada database
+// @Description Mengambil seluruh data produk pada database, bisa difilter berdasarkan supplier, produk induk, dan atribut varian. Stok produk induk adalah jumlah stok variannya.
 // @Tags Product
 // @Produce json
 // @Security BearerAuth
+// @Param category_id query int false "Filter kategori, termasuk seluruh subkategorinya"
+// @Param supplier_id query int false "Hanya produk yang dipasok supplier ini"
+// @Param parent_id query int false "Hanya varian dari produk induk ini"
+// @Param top_level query bool false "Jika true, varian tidak ditampilkan (hanya produk induk dan produk biasa)"
+// @Param attributes query string false "Filter atribut varian, format name:value dipisah koma (contoh: size:M,color:Red)"
 // @Success 200 {object} web.WebResponse{data=[]web.ProductResponse}
-// @Failure 500 {object} web.WebResponse
+// @Failure 400,500 {object} web.WebResponse
 // @Router /products [get]
 func (c *ProductController) FindAll(ctx *fiber.Ctx) error {
-	result, err := c.Service.FindAll()
+	filters := map[string]interface{}{}
+	if categoryID := ctx.Query("category_id"); categoryID != "" {
+		id, err := strconv.Atoi(categoryID)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid category_id",
+			})
+		}
+		filters["category_id"] = id
+	}
+	if supplierID := ctx.Query("supplier_id"); supplierID != "" {
+		id, err := strconv.Atoi(supplierID)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid supplier_id",
+			})
+		}
+		filters["supplier_id"] = id
+	}
+	if parentID := ctx.Query("parent_id"); parentID != "" {
+		id, err := strconv.Atoi(parentID)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid parent_id",
+			})
+		}
+		filters["parent_id"] = id
+	}
+	if ctx.Query("top_level") == "true" {
+		filters["top_level"] = true
+	}
+	if attributes := ctx.Query("attributes"); attributes != "" {
+		parsed := map[string]string{}
+		for _, pair := range strings.Split(attributes, ",") {
+			name, value, ok := strings.Cut(pair, ":")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if !ok || name == "" {
+				return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+					Code:   http.StatusBadRequest,
+					Status: "BAD REQUEST",
+					Error:  "invalid attributes, use name:value pairs separated by comma",
+				})
+			}
+			parsed[name] = strings.TrimSpace(value)
+		}
+		filters["attributes"] = parsed
+	}
+
+	result, err := c.Service.FindAll(filters)
 	if err != nil {
 		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   http.StatusInternalServerError,
@@ -49,6 +109,7 @@ func (c *ProductController) FindAll(ctx *fiber.Ctx) error {
 // @Tags Product
 // @Produce json
 // @Param id path int true "Product ID"
+// @Param unit query string false "Kode satuan untuk menampilkan stok (display)"
 // @Success 200 {object} web.WebResponse{data=web.ProductResponse}
 // @Failure 400,404 {object} web.WebResponse
 // @Router /products/{id} [get]
@@ -62,8 +123,15 @@ func (c *ProductController) FindById(ctx *fiber.Ctx) error {
 		})
 	}
 
-	result, err := c.Service.FindById(id)
+	result, err := c.Service.FindById(id, ctx.Query("unit"))
 	if err != nil {
+		if err.Error() == "unit not found" || err.Error() == "unit not configured for product" {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
 		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
 			Code:   http.StatusNotFound,
 			Status: "NOT FOUND",
@@ -78,16 +146,58 @@ func (c *ProductController) FindById(ctx *fiber.Ctx) error {
 	})
 }
 
+// Lookup godoc
+// @Summary Cari produk berdasarkan SKU atau barcode
+// @Description Mencari satu produk dari SKU atau barcode (misalnya hasil scan), lengkap dengan stok per gudang
+// @Tags Product
+// @Produce json
+// @Security BearerAuth
+// @Param code query string true "SKU atau barcode"
+// @Success 200 {object} web.WebResponse{data=web.ProductResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/lookup [get]
+func (c *ProductController) Lookup(ctx *fiber.Ctx) error {
+	result, err := c.Service.Lookup(ctx.Query("code"))
+	if err != nil {
+		switch err.Error() {
+		case "code is required":
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		case "product not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Product not found",
+			})
+		default:
+			return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+				Code:   http.StatusInternalServerError,
+				Status: "INTERNAL SERVER ERROR",
+				Error:  err.Error(),
+			})
+		}
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
 // Create godoc
 // @Summary Membuat Produk baru
-// @Description Membuat produk baru (hanya bisa oleh admin)
+// @Description Membuat produk baru (hanya bisa oleh admin). Produk dibuat dengan stok 0; stok awal dicatat lewat stock movement in atau adjust ke gudang tertentu.
 // @Tags Product
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body web.ProductCreateOrUpdateRequest true "Product Data"
 // @Success 201 {object} web.WebResponse{data=web.ProductResponse}
-// @Failure 400 {object} web.WebResponse
+// @Failure 400,409 {object} web.WebResponse
 // @Router /products [post]
 func (c *ProductController) Create(ctx *fiber.Ctx) error {
 	var req web.ProductCreateOrUpdateRequest
@@ -101,6 +211,13 @@ func (c *ProductController) Create(ctx *fiber.Ctx) error {
 
 	result, err := c.Service.Create(req)
 	if err != nil {
+		if err.Error() == "sku already in use" {
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		}
 		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -117,7 +234,7 @@ func (c *ProductController) Create(ctx *fiber.Ctx) error {
 
 // Update godoc
 // @Summary Perbarui data produk
-// @Description Endpoint ini digunakan untuk memperbarui informasi produk berdasarkan ID.
+// @Description Endpoint ini digunakan untuk memperbarui informasi produk berdasarkan ID. Stok tidak bisa diubah di sini, gunakan stock movement. Serialized hanya bisa diubah selama produk belum punya stok maupun serial number dan tidak terkait kit; varian selalu mengikuti produk induknya.
 // @Tags Product
 // @Accept json
 // @Produce json
@@ -126,6 +243,7 @@ func (c *ProductController) Create(ctx *fiber.Ctx) error {
 // @Success 200 {object} web.WebResponse{data=web.ProductResponse}
 // @Failure 400 {object} web.WebResponse
 // @Failure 404 {object} web.WebResponse
+// @Failure 409 {object} web.WebResponse
 // @Security BearerAuth
 // @Router /products/{id} [put]
 func (c *ProductController) Update(ctx *fiber.Ctx) error {
@@ -156,6 +274,16 @@ func (c *ProductController) Update(ctx *fiber.Ctx) error {
 				Error:  "Product not found",
 			})
 		}
+		switch err.Error() {
+		case "sku already in use", "parent product cannot change serialized",
+			"cannot change serialized while product has stock", "cannot change serialized while product has serial numbers",
+			"serialized product cannot be a kit", "serialized product cannot be a kit component":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		}
 		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -172,13 +300,14 @@ func (c *ProductController) Update(ctx *fiber.Ctx) error {
 
 // Delete godoc
 // @Summary Hapus produk
-// @Description Endpoint ini digunakan untuk menghapus produk berdasarkan ID.
+// @Description Endpoint ini digunakan untuk menghapus produk berdasarkan ID. Produk dipindahkan ke trash (soft delete) dan bisa dipulihkan.
 // @Tags Product
 // @Produce json
 // @Param id path int true "ID Produk yang akan dihapus"
 // @Success 200 {object} web.WebResponse
 // @Failure 400 {object} web.WebResponse
 // @Failure 404 {object} web.WebResponse
+// @Failure 409 {object} web.WebResponse
 // @Security BearerAuth
 // @Router /products/{id} [delete]
 func (c *ProductController) Delete(ctx *fiber.Ctx) error {
@@ -193,6 +322,13 @@ func (c *ProductController) Delete(ctx *fiber.Ctx) error {
 
 	err = c.Service.Delete(id)
 	if err != nil {
+		if err.Error() == "product has variants" || err.Error() == "product is used as kit component" {
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		}
 		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
 			Code:   http.StatusNotFound,
 			Status: "NOT FOUND",
@@ -241,3 +377,261 @@ func (c *ProductController) Search(ctx *fiber.Ctx) error {
 		Data:   results,
 	})
 }
+
+// LowStock godoc
+// @Summary Produk dengan stok menipis
+// @Description Mengambil produk yang stoknya di bawah ambang batas (nilai terbesar antara min_stock dan reorder_point), beserta kekurangan, reorder quantity, dan alert yang masih open.
+// @Tags Product
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} web.WebResponse{data=[]web.LowStockProductResponse}
+// @Failure 500 {object} web.WebResponse
+// @Router /products/low-stock [get]
+func (c *ProductController) LowStock(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindLowStock()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindVariants godoc
+// @Summary Varian sebuah produk
+// @Description Mengambil semua varian produk induk beserta atribut, SKU, dan stoknya masing-masing
+// @Tags Product
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID produk induk"
+// @Success 200 {object} web.WebResponse{data=[]web.ProductResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/variants [get]
+func (c *ProductController) FindVariants(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	result, err := c.Service.FindVariants(id)
+	if err != nil {
+		if err.Error() == "product not found" {
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Product not found",
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// CreateVariant godoc
+// @Summary Membuat varian produk
+// @Description Membuat varian dari produk induk dengan kombinasi atribut (misalnya size dan color) dan SKU sendiri. Kategori, serialized, dan satuan dasar mengikuti produk induk. Produk induk tidak boleh punya stok sendiri. Varian dibuat dengan stok 0; stoknya diisi lewat stock movement ke gudang tertentu.
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID produk induk"
+// @Param request body web.ProductVariantCreateRequest true "Data varian"
+// @Success 201 {object} web.WebResponse{data=web.ProductResponse}
+// @Failure 400,404,409 {object} web.WebResponse
+// @Router /products/{id}/variants [post]
+func (c *ProductController) CreateVariant(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	var req web.ProductVariantCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.CreateVariant(id, req)
+	if err != nil {
+		switch err.Error() {
+		case "product not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Product not found",
+			})
+		case "variant attribute combination already exists", "product with stock cannot have variants", "sku already in use":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		default:
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// FindTrashed godoc
+// @Summary Daftar produk di trash
+// @Description Endpoint ini digunakan untuk mengambil produk yang sudah dihapus (soft delete) dan masih bisa dipulihkan.
+// @Tags Product
+// @Produce json
+// @Success 200 {object} web.WebResponse{data=[]web.ProductResponse}
+// @Failure 500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /products/trash [get]
+func (c *ProductController) FindTrashed(ctx *fiber.Ctx) error {
+	products, err := c.Service.FindTrashed()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   products,
+	})
+}
+
+// Restore godoc
+// @Summary Pulihkan produk dari trash
+// @Description Endpoint ini digunakan untuk memulihkan produk yang sudah dihapus. Produk induk dan kategorinya harus aktif.
+// @Tags Product
+// @Produce json
+// @Param id path int true "ID Produk"
+// @Success 200 {object} web.WebResponse{data=web.ProductResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /products/trash/{id}/restore [post]
+func (c *ProductController) Restore(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	product, err := c.Service.Restore(id)
+	if err != nil {
+		switch err.Error() {
+		case "product not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Product not found in trash",
+			})
+		case "parent product is deleted", "product category is deleted":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   product,
+	})
+}
+
+// Purge godoc
+// @Summary Hapus permanen produk dari trash
+// @Description Endpoint ini digunakan untuk menghapus permanen produk di trash. Ditolak (409) selama produk masih dirujuk movement, reservasi, order, stocktake, atau varian; jumlah rujukannya dikirim di data.
+// @Tags Product
+// @Produce json
+// @Param id path int true "ID Produk"
+// @Success 200 {object} web.WebResponse{data=web.PurgeResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Failure 409 {object} web.WebResponse{data=web.PurgeResponse}
+// @Security BearerAuth
+// @Router /products/trash/{id} [delete]
+func (c *ProductController) Purge(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	result, err := c.Service.Purge(id)
+	if err != nil {
+		switch err.Error() {
+		case "product not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Product not found in trash",
+			})
+		case "product is still referenced":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Data:   result,
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
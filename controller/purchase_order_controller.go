@@ -0,0 +1,321 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PurchaseOrderController struct {
+	Service service.PurchaseOrderService
+}
+
+func NewPurchaseOrderController(s service.PurchaseOrderService) *PurchaseOrderController {
+	return &PurchaseOrderController{Service: s}
+}
+
+// FindAll godoc
+// @Summary Ambil semua purchase order
+// @Description Mengambil seluruh purchase order beserta baris dan jumlah yang sudah diterima. Bisa difilter berdasarkan status.
+// @Tags PurchaseOrder
+// @Produce json
+// @Param status query string false "Filter status (draft, ordered, partially_received, received, cancelled)"
+// @Success 200 {object} web.WebResponse{data=[]web.PurchaseOrderResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /purchase-orders [get]
+func (c *PurchaseOrderController) FindAll(ctx *fiber.Ctx) error {
+	filters := map[string]interface{}{}
+	if status := ctx.Query("status"); status != "" {
+		switch status {
+		case "draft", "ordered", "partially_received", "received", "cancelled":
+			filters["status"] = status
+		default:
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid status",
+			})
+		}
+	}
+
+	result, err := c.Service.FindAll(filters)
+	if err != nil {
+		return purchaseOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindById godoc
+// @Summary Ambil purchase order berdasarkan ID
+// @Description Mengambil satu purchase order beserta barisnya dan movement penerimaan yang sudah diposting.
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "ID purchase order"
+// @Success 200 {object} web.WebResponse{data=web.PurchaseOrderResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /purchase-orders/{id} [get]
+func (c *PurchaseOrderController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.FindById(id)
+	if err != nil {
+		return purchaseOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Buat purchase order
+// @Description Admin membuat purchase order berstatus draft untuk satu supplier dengan satu atau lebih baris produk.
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param request body web.PurchaseOrderCreateOrUpdateRequest true "Data purchase order"
+// @Success 201 {object} web.WebResponse{data=web.PurchaseOrderResponse}
+// @Failure 400,401,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /purchase-orders [post]
+func (c *PurchaseOrderController) Create(ctx *fiber.Ctx) error {
+	var req web.PurchaseOrderCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Create(userID, req)
+	if err != nil {
+		return purchaseOrderErrorResponse(ctx, err)
+	}
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// Update godoc
+// @Summary Ubah purchase order
+// @Description Mengganti header dan seluruh baris purchase order. Hanya purchase order berstatus draft yang bisa diubah.
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path int true "ID purchase order"
+// @Param request body web.PurchaseOrderCreateOrUpdateRequest true "Data purchase order"
+// @Success 200 {object} web.WebResponse{data=web.PurchaseOrderResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /purchase-orders/{id} [put]
+func (c *PurchaseOrderController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	var req web.PurchaseOrderCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Update(id, req)
+	if err != nil {
+		return purchaseOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Order godoc
+// @Summary Kirim purchase order ke supplier
+// @Description Mengubah status purchase order dari draft menjadi ordered sehingga barang bisa diterima.
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "ID purchase order"
+// @Success 200 {object} web.WebResponse{data=web.PurchaseOrderResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /purchase-orders/{id}/order [post]
+func (c *PurchaseOrderController) Order(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.Order(id)
+	if err != nil {
+		return purchaseOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Cancel godoc
+// @Summary Batalkan purchase order
+// @Description Membatalkan purchase order berstatus draft atau ordered yang belum menerima barang.
+// @Tags PurchaseOrder
+// @Produce json
+// @Param id path int true "ID purchase order"
+// @Success 200 {object} web.WebResponse{data=web.PurchaseOrderResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /purchase-orders/{id}/cancel [post]
+func (c *PurchaseOrderController) Cancel(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.Cancel(id)
+	if err != nil {
+		return purchaseOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Receive godoc
+// @Summary Terima barang dari purchase order
+// @Description Mencatat barang yang diterima per baris purchase order. Setiap baris memposting stok masuk dengan reference purchase-order:<id> dalam satu transaksi.
+// @Description Penerimaan melebihi sisa pesanan ditolak, kecuali admin mengirim allow_over_receipt = true.
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path int true "ID purchase order"
+// @Param request body web.PurchaseOrderReceiveRequest true "Baris yang diterima"
+// @Success 200 {object} web.WebResponse{data=web.PurchaseOrderResponse}
+// @Failure 400,401,403,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /purchase-orders/{id}/receive [post]
+func (c *PurchaseOrderController) Receive(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	var req web.PurchaseOrderReceiveRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+	isAdmin := ctx.Locals("role") == "admin"
+
+	result, err := c.Service.Receive(id, userID, isAdmin, req)
+	if err != nil {
+		return purchaseOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+func purchaseOrderErrorResponse(ctx *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case msg == "purchase order not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  msg,
+		})
+	case msg == "purchase order is not a draft", msg == "purchase order status does not allow this action",
+		msg == "purchase order is not open for receiving":
+		return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+			Code:   http.StatusConflict,
+			Status: "CONFLICT",
+			Error:  msg,
+		})
+	case msg == "only admin can allow over receipt":
+		return ctx.Status(http.StatusForbidden).JSON(web.WebResponse{
+			Code:   http.StatusForbidden,
+			Status: "FORBIDDEN",
+			Error:  msg,
+		})
+	case msg == "duplicate product in purchase order", msg == "line is not part of this purchase order",
+		msg == "quantity exceeds remaining order quantity", stockMovementBadRequests[msg]:
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  msg,
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  msg,
+		})
+	}
+}
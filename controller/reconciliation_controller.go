@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReconciliationController struct {
+	Service service.ReconciliationService
+}
+
+func NewReconciliationController(s service.ReconciliationService) *ReconciliationController {
+	return &ReconciliationController{Service: s}
+}
+
+// Check godoc
+// @Summary Rekonsiliasi stok dengan stock movement
+// @Description Menghitung ulang saldo setiap produk per gudang dari stock movement dan menampilkan produk yang stok tersimpannya berbeda. Tidak mengubah data.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} web.WebResponse{data=web.ReconciliationResponse}
+// @Failure 500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /admin/reconciliation [get]
+func (c *ReconciliationController) Check(ctx *fiber.Ctx) error {
+	result, err := c.Service.Check()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Repair godoc
+// @Summary Perbaiki selisih stok
+// @Description Mencatat movement adjust ber-reference "reconciliation:<waktu>" untuk setiap selisih stok per gudang sehingga saldo movement sama dengan stok tersimpan, lalu menyamakan total stok produk dengan jumlah saldo gudang.
+// @Tags Admin
+// @Produce json
+// @Param Idempotency-Key header string false "Key unik agar retry tidak memperbaiki dua kali"
+// @Success 200 {object} web.WebResponse{data=web.ReconciliationResponse}
+// @Failure 401,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /admin/reconciliation/repair [post]
+func (c *ReconciliationController) Repair(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Repair(userID)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReplenishmentController struct {
+	Service service.ReplenishmentService
+}
+
+func NewReplenishmentController(s service.ReplenishmentService) *ReplenishmentController {
+	return &ReplenishmentController{Service: s}
+}
+
+// Suggest godoc
+// @Summary Saran pembelian berdasarkan pemakaian
+// @Description Menghitung pemakaian rata-rata harian per produk dari movement keluar, perkiraan berapa hari stok cukup dan tanggal stok habis, serta jumlah yang disarankan untuk dipesan berdasarkan lead time dan safety stock. Bisa diekspor ke CSV.
+// @Tags Report
+// @Produce json
+// @Param window_days query int false "Jumlah hari ke belakang untuk menghitung pemakaian (default: 30)"
+// @Param lead_time_days query int false "Lead time untuk produk tanpa supplier preferred (default: 7)"
+// @Param safety_days query int false "Safety stock dalam hari pemakaian (default: 7)"
+// @Param supplier_id query int false "Hanya produk yang dipasok supplier ini"
+// @Param only_needed query bool false "Hanya tampilkan produk yang perlu dipesan"
+// @Param export query string false "Jika bernilai 'csv', maka file akan didownload dalam format CSV"
+// @Success 200 {object} web.WebResponse{data=[]web.ReplenishmentSuggestionResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /reports/replenishment [get]
+func (c *ReplenishmentController) Suggest(ctx *fiber.Ctx) error {
+	query := web.ReplenishmentQuery{
+		WindowDays:   30,
+		LeadTimeDays: 7,
+		SafetyDays:   7,
+		OnlyNeeded:   ctx.QueryBool("only_needed"),
+	}
+
+	params := map[string]*int{
+		"window_days":    &query.WindowDays,
+		"lead_time_days": &query.LeadTimeDays,
+		"safety_days":    &query.SafetyDays,
+		"supplier_id":    &query.SupplierID,
+	}
+	for name, target := range params {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid " + name,
+			})
+		}
+		*target = parsed
+	}
+
+	data, err := c.Service.Suggest(query)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error:") {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	// Export CSV jika diminta
+	if ctx.Query("export") == "csv" {
+		var b bytes.Buffer
+		writer := csv.NewWriter(&b)
+
+		writer.Write([]string{"Product ID", "Name", "On Hand", "Reserved", "Available", "On Order", "Consumed", "Window Days", "Avg Daily Consumption", "Days Of Cover", "Stockout Date", "Lead Time Days", "Safety Stock", "Reorder Level", "Suggested Quantity", "Supplier ID", "Supplier", "Supplier SKU"})
+		for _, r := range data {
+			daysOfCover := ""
+			if r.DaysOfCover != nil {
+				daysOfCover = strconv.FormatFloat(*r.DaysOfCover, 'f', 1, 64)
+			}
+			writer.Write([]string{
+				strconv.Itoa(r.ProductID),
+				r.Name,
+				strconv.Itoa(r.OnHand),
+				strconv.Itoa(r.Reserved),
+				strconv.Itoa(r.Available),
+				strconv.Itoa(r.OnOrder),
+				strconv.Itoa(r.Consumed),
+				strconv.Itoa(r.WindowDays),
+				strconv.FormatFloat(r.AvgDailyConsumption, 'f', 2, 64),
+				daysOfCover,
+				r.StockoutDate,
+				strconv.Itoa(r.LeadTimeDays),
+				strconv.Itoa(r.SafetyStock),
+				strconv.Itoa(r.ReorderLevel),
+				strconv.Itoa(r.SuggestedQuantity),
+				optionalIntString(r.SupplierID),
+				r.Supplier,
+				r.SupplierSKU,
+			})
+		}
+		writer.Flush()
+
+		timestamp := time.Now().Format("20060102_150405")
+		filename := fmt.Sprintf("replenishment_%s.csv", timestamp)
+
+		ctx.Set("Content-Type", "text/csv")
+		ctx.Set("Content-Disposition", "attachment; filename="+filename)
+		return ctx.Send(b.Bytes())
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   data,
+	})
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReservationController struct {
+	Service service.ReservationService
+}
+
+func NewReservationController(s service.ReservationService) *ReservationController {
+	return &ReservationController{Service: s}
+}
+
+// FindAll godoc
+// @Summary Ambil semua reservasi stok
+// @Description Mengambil reservasi stok, bisa difilter berdasarkan produk, gudang, dan status (active, consumed, released, expired).
+// @Tags Reservation
+// @Produce json
+// @Param product_id query int false "Filter berdasarkan ID produk"
+// @Param warehouse_id query int false "Filter berdasarkan ID gudang"
+// @Param status query string false "Filter berdasarkan status"
+// @Success 200 {object} web.WebResponse{data=[]web.ReservationResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /reservations [get]
+func (c *ReservationController) FindAll(ctx *fiber.Ctx) error {
+	filters, err := parseLotFilters(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+	if status := ctx.Query("status"); status != "" {
+		switch status {
+		case "active", "consumed", "released", "expired":
+			filters["status"] = status
+		default:
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid status",
+			})
+		}
+	}
+
+	result, err := c.Service.FindAll(filters)
+	if err != nil {
+		return reservationErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindById godoc
+// @Summary Ambil reservasi berdasarkan ID
+// @Description Mengambil satu reservasi stok beserta sisa jumlah yang masih ditahan.
+// @Tags Reservation
+// @Produce json
+// @Param id path int true "ID reservasi"
+// @Success 200 {object} web.WebResponse{data=web.ReservationResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /reservations/{id} [get]
+func (c *ReservationController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.FindById(id)
+	if err != nil {
+		return reservationErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Buat reservasi stok
+// @Description Menahan stok produk di satu gudang untuk pekerjaan yang belum dikirim. Stok fisik tidak berubah, tetapi available berkurang. Reservasi dengan expires_at otomatis lepas setelah lewat waktunya.
+// @Tags Reservation
+// @Accept json
+// @Produce json
+// @Param request body web.ReservationCreateRequest true "Data reservasi"
+// @Success 201 {object} web.WebResponse{data=web.ReservationResponse}
+// @Failure 400,401,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /reservations [post]
+func (c *ReservationController) Create(ctx *fiber.Ctx) error {
+	var req web.ReservationCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Create(userID, req)
+	if err != nil {
+		return reservationErrorResponse(ctx, err)
+	}
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// Release godoc
+// @Summary Lepaskan reservasi
+// @Description Melepaskan sisa stok yang ditahan reservasi sehingga kembali tersedia.
+// @Tags Reservation
+// @Produce json
+// @Param id path int true "ID reservasi"
+// @Success 200 {object} web.WebResponse{data=web.ReservationResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /reservations/{id}/release [post]
+func (c *ReservationController) Release(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.Release(id)
+	if err != nil {
+		return reservationErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+func reservationErrorResponse(ctx *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch msg {
+	case "reservation not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  msg,
+		})
+	case "reservation is not active":
+		return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+			Code:   http.StatusConflict,
+			Status: "CONFLICT",
+			Error:  msg,
+		})
+	case "validation failed", "expires_at must be in the future", "product not found",
+		"warehouse not found", "available stock not enough":
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  msg,
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  msg,
+		})
+	}
+}
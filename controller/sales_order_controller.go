@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SalesOrderController struct {
+	Service service.SalesOrderService
+}
+
+func NewSalesOrderController(s service.SalesOrderService) *SalesOrderController {
+	return &SalesOrderController{Service: s}
+}
+
+// FindAll godoc
+// @Summary Ambil semua sales order
+// @Description Mengambil seluruh order pengeluaran barang beserta jumlah terkirim, tertahan, dan backorder per baris. Bisa difilter berdasarkan status.
+// @Tags SalesOrder
+// @Produce json
+// @Param status query string false "Filter status (draft, confirmed, picked, shipped, cancelled)"
+// @Success 200 {object} web.WebResponse{data=[]web.SalesOrderResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders [get]
+func (c *SalesOrderController) FindAll(ctx *fiber.Ctx) error {
+	filters := map[string]interface{}{}
+	if status := ctx.Query("status"); status != "" {
+		switch status {
+		case "draft", "confirmed", "picked", "shipped", "cancelled":
+			filters["status"] = status
+		default:
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid status",
+			})
+		}
+	}
+
+	result, err := c.Service.FindAll(filters)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindById godoc
+// @Summary Ambil sales order berdasarkan ID
+// @Description Mengambil satu sales order beserta barisnya dan movement pengiriman yang sudah diposting.
+// @Tags SalesOrder
+// @Produce json
+// @Param id path int true "ID sales order"
+// @Success 200 {object} web.WebResponse{data=web.SalesOrderResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders/{id} [get]
+func (c *SalesOrderController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.FindById(id)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Buat sales order
+// @Description Membuat order pengeluaran barang berstatus draft untuk customer atau departemen peminta.
+// @Tags SalesOrder
+// @Accept json
+// @Produce json
+// @Param request body web.SalesOrderCreateOrUpdateRequest true "Data sales order"
+// @Success 201 {object} web.WebResponse{data=web.SalesOrderResponse}
+// @Failure 400,401,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders [post]
+func (c *SalesOrderController) Create(ctx *fiber.Ctx) error {
+	var req web.SalesOrderCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Create(userID, req)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// Update godoc
+// @Summary Ubah sales order
+// @Description Mengganti header dan seluruh baris sales order. Hanya order berstatus draft yang bisa diubah.
+// @Tags SalesOrder
+// @Accept json
+// @Produce json
+// @Param id path int true "ID sales order"
+// @Param request body web.SalesOrderCreateOrUpdateRequest true "Data sales order"
+// @Success 200 {object} web.WebResponse{data=web.SalesOrderResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders/{id} [put]
+func (c *SalesOrderController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	var req web.SalesOrderCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Update(id, req)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Confirm godoc
+// @Summary Konfirmasi sales order
+// @Description Mengonfirmasi order draft dan menahan stok yang tersedia untuk setiap baris lewat reservasi. Kekurangan stok dicatat sebagai backorder.
+// @Tags SalesOrder
+// @Produce json
+// @Param id path int true "ID sales order"
+// @Success 200 {object} web.WebResponse{data=web.SalesOrderResponse}
+// @Failure 400,401,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders/{id}/confirm [post]
+func (c *SalesOrderController) Confirm(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Confirm(id, userID)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Pick godoc
+// @Summary Tandai sales order sudah di-pick
+// @Description Staff menandai barang order sudah diambil dari rak dan siap dikirim.
+// @Tags SalesOrder
+// @Produce json
+// @Param id path int true "ID sales order"
+// @Success 200 {object} web.WebResponse{data=web.SalesOrderResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders/{id}/pick [post]
+func (c *SalesOrderController) Pick(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.Pick(id)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Ship godoc
+// @Summary Kirim barang sales order
+// @Description Staff mengirim barang per baris order. Setiap baris memposting stok keluar dengan reference sales-order:<id> dalam satu transaksi, memakai stok yang ditahan reservasi order.
+// @Description Pengiriman boleh sebagian; order berstatus shipped setelah semua baris terkirim penuh.
+// @Tags SalesOrder
+// @Accept json
+// @Produce json
+// @Param id path int true "ID sales order"
+// @Param request body web.SalesOrderShipRequest true "Baris yang dikirim"
+// @Success 200 {object} web.WebResponse{data=web.SalesOrderResponse}
+// @Failure 400,401,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders/{id}/ship [post]
+func (c *SalesOrderController) Ship(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	var req web.SalesOrderShipRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Ship(id, userID, req)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Cancel godoc
+// @Summary Batalkan sales order
+// @Description Membatalkan order yang belum terkirim penuh dan melepas semua stok yang ditahan untuk order tersebut.
+// @Tags SalesOrder
+// @Produce json
+// @Param id path int true "ID sales order"
+// @Success 200 {object} web.WebResponse{data=web.SalesOrderResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /sales-orders/{id}/cancel [post]
+func (c *SalesOrderController) Cancel(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.Cancel(id)
+	if err != nil {
+		return salesOrderErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+func salesOrderErrorResponse(ctx *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case msg == "sales order not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  msg,
+		})
+	case msg == "sales order is not a draft", msg == "sales order status does not allow this action",
+		msg == "sales order is not open for shipping":
+		return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+			Code:   http.StatusConflict,
+			Status: "CONFLICT",
+			Error:  msg,
+		})
+	case msg == "duplicate product in sales order", msg == "line is not part of this sales order",
+		msg == "quantity exceeds remaining order quantity", stockMovementBadRequests[msg]:
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  msg,
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  msg,
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SerialNumberController struct {
+	Service service.SerialNumberService
+}
+
+func NewSerialNumberController(s service.SerialNumberService) *SerialNumberController {
+	return &SerialNumberController{Service: s}
+}
+
+// FindBySerial godoc
+// @Summary Lacak unit berdasarkan serial number
+// @Description Mengambil status terkini (in_stock/out), lokasi gudang, dan seluruh riwayat pergerakan stok dari satu unit.
+// @Tags SerialNumber
+// @Produce json
+// @Param serial path string true "Serial number unit"
+// @Success 200 {object} web.WebResponse{data=web.SerialNumberResponse}
+// @Failure 404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /serials/{serial} [get]
+func (c *SerialNumberController) FindBySerial(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindBySerial(ctx.Params("serial"))
+	if err != nil {
+		if err.Error() == "serial number not found" {
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
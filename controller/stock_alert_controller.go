@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockAlertController struct {
+	Service service.StockAlertService
+}
+
+func NewStockAlertController(s service.StockAlertService) *StockAlertController {
+	return &StockAlertController{Service: s}
+}
+
+// FindAll godoc
+// @Summary Ambil alert stok menipis
+// @Description Mengambil alert yang dibuat saat movement membuat stok produk turun di bawah ambang batas. Alert open otomatis resolved ketika stok kembali pulih.
+// @Tags Stock Alert
+// @Produce json
+// @Param product_id query int false "Filter berdasarkan ID produk"
+// @Param status query string false "Filter berdasarkan status (open, resolved)"
+// @Success 200 {object} web.WebResponse{data=[]web.StockAlertResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stock-alerts [get]
+func (c *StockAlertController) FindAll(ctx *fiber.Ctx) error {
+	filters := map[string]interface{}{}
+	if productID := ctx.Query("product_id"); productID != "" {
+		id, err := strconv.Atoi(productID)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid product_id",
+			})
+		}
+		filters["product_id"] = id
+	}
+	if status := ctx.Query("status"); status != "" {
+		if status != "open" && status != "resolved" {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid status",
+			})
+		}
+		filters["status"] = status
+	}
+
+	result, err := c.Service.FindAll(filters)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
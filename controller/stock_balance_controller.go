@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockBalanceController struct {
+	Service service.StockBalanceService
+}
+
+func NewStockBalanceController(s service.StockBalanceService) *StockBalanceController {
+	return &StockBalanceController{Service: s}
+}
+
+// ProductBalance godoc
+// @Summary Saldo stok produk pada tanggal tertentu
+// @Description Menghitung saldo stok produk (total dan per gudang) pada akhir hari as_of dari riwayat stock movement.
+// @Tags Product
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param as_of query string false "Tanggal YYYY-MM-DD (default: hari ini)"
+// @Param unit query string false "Kode satuan untuk menampilkan quantity (display_quantity)"
+// @Success 200 {object} web.WebResponse{data=web.StockBalanceResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /products/{id}/stock [get]
+func (c *StockBalanceController) ProductBalance(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	result, err := c.Service.ProductBalance(id, ctx.Query("as_of"), ctx.Query("unit"))
+	if err != nil {
+		return stockBalanceErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Report godoc
+// @Summary Laporan saldo stok pada tanggal tertentu
+// @Description Menghitung saldo stok semua produk (total dan per gudang) pada akhir hari as_of dari riwayat stock movement, bisa difilter berdasarkan kategori dan diekspor ke CSV.
+// @Tags Report
+// @Produce json
+// @Param as_of query string false "Tanggal YYYY-MM-DD (default: hari ini)"
+// @Param unit query string false "Kode satuan untuk menampilkan quantity (display_quantity)"
+// @Param category_id query int false "Filter berdasarkan ID kategori, termasuk seluruh subkategorinya"
+// @Param export query string false "Jika bernilai 'csv', maka file akan didownload dalam format CSV"
+// @Success 200 {object} web.WebResponse{data=[]web.StockBalanceResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /reports/stock-balance [get]
+func (c *StockBalanceController) Report(ctx *fiber.Ctx) error {
+	filters := map[string]interface{}{}
+	if categoryID := ctx.Query("category_id"); categoryID != "" {
+		id, err := strconv.Atoi(categoryID)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid category_id",
+			})
+		}
+		filters["category_id"] = id
+	}
+
+	data, err := c.Service.Report(ctx.Query("as_of"), ctx.Query("unit"), filters)
+	if err != nil {
+		return stockBalanceErrorResponse(ctx, err)
+	}
+
+	// Export CSV jika diminta, satu baris per produk per gudang
+	if ctx.Query("export") == "csv" {
+		var b bytes.Buffer
+		writer := csv.NewWriter(&b)
+
+		writer.Write([]string{"As Of", "Product ID", "Name", "Category ID", "Warehouse ID", "Warehouse", "Quantity", "Display Quantity"})
+		for _, p := range data {
+			for _, w := range p.Warehouses {
+				writer.Write([]string{
+					p.AsOf,
+					strconv.Itoa(p.ProductID),
+					p.Name,
+					strconv.Itoa(p.CategoryID),
+					strconv.Itoa(w.WarehouseID),
+					w.Warehouse,
+					strconv.Itoa(w.Quantity),
+					optionalFloatString(w.DisplayQuantity),
+				})
+			}
+		}
+		writer.Flush()
+
+		timestamp := time.Now().Format("20060102_150405")
+		filename := fmt.Sprintf("stock_balance_%s.csv", timestamp)
+
+		ctx.Set("Content-Type", "text/csv")
+		ctx.Set("Content-Disposition", "attachment; filename="+filename)
+		return ctx.Send(b.Bytes())
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   data,
+	})
+}
+
+func stockBalanceErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "invalid as_of date", "unit not found":
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	case "product not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  "Product not found",
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"errors"
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockDocumentController struct {
+	Service service.StockDocumentService
+}
+
+func NewStockDocumentController(s service.StockDocumentService) *StockDocumentController {
+	return &StockDocumentController{Service: s}
+}
+
+// FindAll godoc
+// @Summary Ambil semua dokumen stok
+// @Description Mengambil seluruh dokumen stok (penerimaan, pengeluaran, transfer) beserta movement tiap barisnya.
+// @Tags Stock Documents
+// @Produce json
+// @Success 200 {object} web.WebResponse{data=[]web.StockDocumentResponse}
+// @Failure 500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stock-documents [get]
+func (c *StockDocumentController) FindAll(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindAll()
+	if err != nil {
+		return stockDocumentErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindById godoc
+// @Summary Ambil dokumen stok berdasarkan ID
+// @Description Mengambil satu dokumen stok beserta seluruh movement yang diposting darinya.
+// @Tags Stock Documents
+// @Produce json
+// @Param id path int true "ID dokumen stok"
+// @Success 200 {object} web.WebResponse{data=web.StockDocumentResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stock-documents/{id} [get]
+func (c *StockDocumentController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.FindById(id)
+	if err != nil {
+		return stockDocumentErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Posting dokumen stok multi baris
+// @Description Staff memposting satu dokumen (header + banyak baris produk). Semua baris divalidasi terlebih dahulu, termasuk kecukupan stok untuk type out/transfer. Jika ada baris yang tidak valid, field error berisi daftar kesalahan per baris dan tidak ada stok yang berubah. Semua movement diposting dalam satu transaksi dengan reference stock-document:{id}; reference_number hanya disimpan di dokumen.
+// @Tags Stock Documents
+// @Accept json
+// @Produce json
+// @Param request body web.StockDocumentCreateRequest true "Header dan baris dokumen"
+// @Param Idempotency-Key header string false "Key unik per transaksi; retry dengan key yang sama membalas response pertama tanpa memposting ulang"
+// @Success 201 {object} web.WebResponse{data=web.StockDocumentResponse}
+// @Failure 400,401,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stock-documents [post]
+func (c *StockDocumentController) Create(ctx *fiber.Ctx) error {
+	var req web.StockDocumentCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Create(userID, req)
+	if err != nil {
+		return stockDocumentErrorResponse(ctx, err)
+	}
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+func stockDocumentErrorResponse(ctx *fiber.Ctx, err error) error {
+	var lineErr *service.DocumentValidationError
+	if errors.As(err, &lineErr) {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  lineErr.Lines,
+		})
+	}
+
+	msg := err.Error()
+	switch {
+	case msg == "stock document not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  msg,
+		})
+	case stockMovementBadRequests[msg]:
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  msg,
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  msg,
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"errors"
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockLotController struct {
+	Service service.StockLotService
+}
+
+func NewStockLotController(s service.StockLotService) *StockLotController {
+	return &StockLotController{Service: s}
+}
+
+// FindAll godoc
+// @Summary Ambil saldo stok per lot
+// @Description Mengambil lot yang masih memiliki stok, diurutkan dari yang paling cepat kedaluwarsa. Bisa difilter berdasarkan produk dan gudang.
+// @Tags StockLot
+// @Produce json
+// @Param product_id query int false "Filter berdasarkan ID produk"
+// @Param warehouse_id query int false "Filter berdasarkan ID gudang"
+// @Success 200 {object} web.WebResponse{data=[]web.StockLotResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /lots [get]
+func (c *StockLotController) FindAll(ctx *fiber.Ctx) error {
+	filters, err := parseLotFilters(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	result, err := c.Service.FindAll(filters)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindExpiring godoc
+// @Summary Ambil lot yang akan kedaluwarsa
+// @Description Mengambil lot yang masih memiliki stok dan kedaluwarsa dalam N hari ke depan, termasuk lot yang sudah kedaluwarsa.
+// @Tags StockLot
+// @Produce json
+// @Param days query int false "Jumlah hari ke depan (default: 30)"
+// @Param product_id query int false "Filter berdasarkan ID produk"
+// @Param warehouse_id query int false "Filter berdasarkan ID gudang"
+// @Success 200 {object} web.WebResponse{data=[]web.StockLotResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /lots/expiring [get]
+func (c *StockLotController) FindExpiring(ctx *fiber.Ctx) error {
+	days, err := strconv.Atoi(ctx.Query("days", "30"))
+	if err != nil || days < 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "invalid days",
+		})
+	}
+
+	filters, err := parseLotFilters(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	result, err := c.Service.FindExpiring(days, filters)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+func parseLotFilters(ctx *fiber.Ctx) (map[string]interface{}, error) {
+	filters := map[string]interface{}{}
+
+	if productID := ctx.Query("product_id"); productID != "" {
+		id, err := strconv.Atoi(productID)
+		if err != nil {
+			return nil, errors.New("invalid product_id")
+		}
+		filters["product_id"] = id
+	}
+
+	if warehouseID := ctx.Query("warehouse_id"); warehouseID != "" {
+		id, err := strconv.Atoi(warehouseID)
+		if err != nil {
+			return nil, errors.New("invalid warehouse_id")
+		}
+		filters["warehouse_id"] = id
+	}
+
+	return filters, nil
+}
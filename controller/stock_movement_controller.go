@@ -13,6 +13,37 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// stockMovementBadRequests berisi pesan error service yang disebabkan input user (dibalas 400)
+var stockMovementBadRequests = map[string]bool{
+	"validation failed":                                true,
+	"product not found":                                true,
+	"stock not enough":                                 true,
+	"warehouse not found":                              true,
+	"source and destination warehouse must differ":     true,
+	"lot not found":                                    true,
+	"lot stock not enough":                             true,
+	"lot expiry date mismatch":                         true,
+	"product is not serialized":                        true,
+	"serial numbers must match quantity":               true,
+	"duplicate serial number":                          true,
+	"serial number belongs to another product":         true,
+	"serial number already in stock":                   true,
+	"serial number not in stock":                       true,
+	"serialized product cannot be adjusted":            true,
+	"available stock not enough":                       true,
+	"reservation not found":                            true,
+	"reservation does not match product or warehouse":  true,
+	"reservation is not active":                        true,
+	"quantity exceeds reservation":                     true,
+	"reservation can only be consumed by out movement": true,
+	"unit cost only allowed for inbound movement":      true,
+	"unit not found":                                   true,
+	"unit not configured for product":                  true,
+	"parent product cannot hold stock":                 true,
+	"component stock not enough":                       true,
+	"product is not a kit":                             true,
+}
+
 type StockMovementController struct {
 	Service service.StockMovementService
 }
@@ -26,13 +57,21 @@ func NewStockMovementController(s service.StockMovementService) *StockMovementCo
 // @Description Endpoint ini digunakan untuk mengambil seluruh data pergerakan stok (masuk & keluar).
 // @Tags StockMovement
 // @Produce json
+// @Param unit query string false "Kode satuan untuk menampilkan quantity (display_quantity)"
 // @Success 200 {object} web.WebResponse{data=[]web.StockMovementResponse}
-// @Failure 500 {object} web.WebResponse
+// @Failure 400,500 {object} web.WebResponse
 // @Security BearerAuth
 // @Router /stock-movements [get]
 func (c *StockMovementController) FindAll(ctx *fiber.Ctx) error {
-	result, err := c.Service.FindAll()
+	result, err := c.Service.FindAll(ctx.Query("unit"))
 	if err != nil {
+		if err.Error() == "unit not found" {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   http.StatusInternalServerError,
 			Status: "INTERNAL SERVER ERROR",
@@ -52,6 +91,7 @@ func (c *StockMovementController) FindAll(ctx *fiber.Ctx) error {
 // @Tags StockMovement
 // @Produce json
 // @Param id path int true "ID pergerakan stok"
+// @Param unit query string false "Kode satuan untuk menampilkan quantity (display_quantity)"
 // @Success 200 {object} web.WebResponse{data=web.StockMovementResponse}
 // @Failure 400,404,500 {object} web.WebResponse
 // @Security BearerAuth
@@ -66,7 +106,7 @@ func (c *StockMovementController) FindById(ctx *fiber.Ctx) error {
 		})
 	}
 
-	result, err := c.Service.FindById(id)
+	result, err := c.Service.FindById(id, ctx.Query("unit"))
 	if err != nil {
 		if err.Error() == "stock movement not found" {
 			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
@@ -75,6 +115,13 @@ func (c *StockMovementController) FindById(ctx *fiber.Ctx) error {
 				Error:  err.Error(),
 			})
 		}
+		if err.Error() == "unit not found" {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   http.StatusInternalServerError,
 			Status: "INTERNAL SERVER ERROR",
@@ -91,11 +138,15 @@ func (c *StockMovementController) FindById(ctx *fiber.Ctx) error {
 
 // Create godoc
 // @Summary Tambah data pergerakan stok baru
-// @Description Endpoint ini digunakan untuk menambah pergerakan stok masuk atau keluar.
+// @Description Endpoint ini digunakan untuk menambah pergerakan stok masuk, keluar, atau transfer antar gudang (isi to_warehouse_id untuk transfer).
+// @Description Produk serialized wajib menyertakan serial_numbers sebanyak quantity.
+// @Description Stok masuk dapat mencatat lot_number dan expiry_date. Stok keluar/transfer dapat menyebut lot_number, jika kosong lot dialokasikan otomatis secara FEFO (lot kedaluwarsa dilewati).
+// @Description Stok keluar/transfer tidak boleh memakai stok yang ditahan reservasi aktif. Isi reservation_id pada stok keluar untuk memakai stok dari reservasi tersebut.
 // @Tags StockMovement
 // @Accept json
 // @Produce json
 // @Param request body web.StockMovementCreateRequest true "Data pergerakan stok"
+// @Param Idempotency-Key header string false "Key unik per transaksi; retry dengan key yang sama membalas response pertama tanpa memposting ulang"
 // @Success 201 {object} web.WebResponse{data=web.StockMovementResponse}
 // @Failure 400,401,500 {object} web.WebResponse
 // @Security BearerAuth
@@ -123,8 +174,8 @@ func (c *StockMovementController) Create(ctx *fiber.Ctx) error {
 	result, err := c.Service.Create(userID, req)
 	if err != nil {
 		msg := err.Error()
-		switch msg {
-		case "validation failed", "product not found", "stock not enough":
+		switch {
+		case stockMovementBadRequests[msg]:
 			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
 				Code:   http.StatusBadRequest,
 				Status: "BAD REQUEST",
@@ -146,17 +197,19 @@ func (c *StockMovementController) Create(ctx *fiber.Ctx) error {
 	})
 }
 
-// Delete godoc
-// @Summary Hapus data pergerakan stok
-// @Description Endpoint ini digunakan untuk menghapus pergerakan stok berdasarkan ID.
+// Reverse godoc
+// @Summary Balik (reverse) data pergerakan stok
+// @Description Endpoint ini membatalkan pergerakan stok dengan memposting pergerakan kebalikannya. Data asli tetap tersimpan dan ditandai reversed. Movement penerimaan purchase order dan pengiriman sales order tidak bisa dibalik (409). DELETE /stock-movements/{id} berperilaku sama; body boleh kosong dan alasannya diisi "deleted via DELETE".
 // @Tags StockMovement
+// @Accept json
 // @Produce json
 // @Param id path int true "ID pergerakan stok"
-// @Success 200 {object} web.WebResponse{data=string}
-// @Failure 400,404,500 {object} web.WebResponse
+// @Param request body web.StockMovementReverseRequest true "Alasan pembalikan"
+// @Success 201 {object} web.WebResponse{data=web.StockMovementResponse}
+// @Failure 400,401,404,409,500 {object} web.WebResponse
 // @Security BearerAuth
-// @Router /stock-movements/{id} [delete]
-func (c *StockMovementController) Delete(ctx *fiber.Ctx) error {
+// @Router /stock-movements/{id}/reverse [post]
+func (c *StockMovementController) Reverse(ctx *fiber.Ctx) error {
 	id, err := strconv.Atoi(ctx.Params("id"))
 	if err != nil {
 		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
@@ -166,26 +219,65 @@ func (c *StockMovementController) Delete(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err = c.Service.Delete(id)
+	var req web.StockMovementReverseRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "Invalid request body",
+			})
+		}
+	}
+	// Client lama memanggil DELETE tanpa body, jadi alasannya diisi otomatis
+	if ctx.Method() == fiber.MethodDelete && req.Reason == "" {
+		req.Reason = "deleted via DELETE"
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Reverse(id, userID, req)
 	if err != nil {
-		if err.Error() == "stock movement not found" {
+		msg := err.Error()
+		switch {
+		case msg == "stock movement not found":
 			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
 				Code:   http.StatusNotFound,
 				Status: "NOT FOUND",
-				Error:  err.Error(),
+				Error:  msg,
+			})
+		case msg == "stock movement already reversed", msg == "cannot reverse a reversal movement", msg == "cannot reverse a reconciliation adjustment", msg == "cannot reverse an order movement":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  msg,
+			})
+		case stockMovementBadRequests[msg]:
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  msg,
+			})
+		default:
+			return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+				Code:   http.StatusInternalServerError,
+				Status: "INTERNAL SERVER ERROR",
+				Error:  msg,
 			})
 		}
-		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Error:  err.Error(),
-		})
 	}
 
-	return ctx.JSON(web.WebResponse{
-		Code:   http.StatusOK,
-		Status: "OK",
-		Data:   "Stock movement deleted",
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
 	})
 }
 
@@ -197,7 +289,10 @@ func (c *StockMovementController) Delete(ctx *fiber.Ctx) error {
 // @Param month query string false "Format bulan: YYYY-MM (contoh: 2024-06)"
 // @Param user_id query int false "Filter berdasarkan ID user"
 // @Param product_id query int false "Filter berdasarkan ID produk"
-// @Param type query string false "Jenis pergerakan (in atau out)"
+// @Param warehouse_id query int false "Filter berdasarkan ID gudang (asal atau tujuan)"
+// @Param type query string false "Jenis pergerakan (in, out, transfer, atau adjust)"
+// @Param supplier_id query int false "Hanya produk yang dipasok supplier ini"
+// @Param unit query string false "Kode satuan untuk menampilkan quantity (display_quantity)"
 // @Param export query string false "Jika bernilai 'csv', maka file akan didownload dalam format CSV"
 // @Success 200 {object} web.WebResponse{data=[]web.StockMovementResponse}
 // @Failure 400,404,500 {object} web.WebResponse
@@ -208,7 +303,9 @@ func (c *StockMovementController) GetMonthlyReport(ctx *fiber.Ctx) error {
 	export := ctx.Query("export")
 	userID := ctx.Query("user_id")
 	productID := ctx.Query("product_id")
+	warehouseID := ctx.Query("warehouse_id")
 	movementType := ctx.Query("type")
+	supplierID := ctx.Query("supplier_id")
 
 	filters := map[string]interface{}{}
 
@@ -238,20 +335,46 @@ func (c *StockMovementController) GetMonthlyReport(ctx *fiber.Ctx) error {
 		}
 	}
 
-	// Validasi type hanya boleh "in" atau "out"
+	// Validasi dan parsing warehouse_id
+	if warehouseID != "" {
+		if id, err := strconv.Atoi(warehouseID); err == nil {
+			filters["warehouse_id"] = id
+		} else {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid warehouse_id",
+			})
+		}
+	}
+
+	// Validasi dan parsing supplier_id
+	if supplierID != "" {
+		if id, err := strconv.Atoi(supplierID); err == nil {
+			filters["supplier_id"] = id
+		} else {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid supplier_id",
+			})
+		}
+	}
+
+	// Validasi type hanya boleh "in", "out", "transfer", atau "adjust"
 	if movementType != "" {
-		if movementType != "in" && movementType != "out" {
+		if movementType != "in" && movementType != "out" && movementType != "transfer" && movementType != "adjust" {
 			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
 				Code:   http.StatusBadRequest,
 				Status: "BAD REQUEST",
-				Error:  "invalid movement type, must be 'in' or 'out'",
+				Error:  "invalid movement type, must be 'in', 'out', 'transfer', or 'adjust'",
 			})
 		}
 		filters["type"] = movementType
 	}
 
 	// Ambil data dari service
-	data, err := c.Service.GetMonthlyReport(month, filters)
+	data, err := c.Service.GetMonthlyReport(month, ctx.Query("unit"), filters)
 	if err != nil {
 		if err.Error() == "report not found" {
 			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
@@ -260,6 +383,13 @@ func (c *StockMovementController) GetMonthlyReport(ctx *fiber.Ctx) error {
 				Error:  "Report not found or filter returned no data",
 			})
 		}
+		if err.Error() == "unit not found" {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
 			Code:   http.StatusInternalServerError,
 			Status: "INTERNAL SERVER ERROR",
@@ -281,16 +411,26 @@ func (c *StockMovementController) GetMonthlyReport(ctx *fiber.Ctx) error {
 		var b bytes.Buffer
 		writer := csv.NewWriter(&b)
 
-		writer.Write([]string{"ID", "Product ID", "User ID", "Type", "Quantity", "Note", "Created At"})
+		writer.Write([]string{"ID", "Product ID", "User ID", "Warehouse ID", "To Warehouse ID", "Type", "Quantity", "Unit", "Unit Quantity", "Display Quantity", "Note", "Reference", "Unit Cost", "Total Cost", "Created At", "Reversed", "Reversal Of ID"})
 		for _, m := range data {
 			writer.Write([]string{
 				strconv.Itoa(m.ID),
 				strconv.Itoa(m.ProductID),
 				strconv.Itoa(m.UserID),
+				strconv.Itoa(m.WarehouseID),
+				optionalIntString(m.ToWarehouseID),
 				m.Type,
 				strconv.Itoa(m.Quantity),
+				m.Unit,
+				strconv.Itoa(m.UnitQuantity),
+				optionalFloatString(m.DisplayQuantity),
 				m.Note,
+				m.Reference,
+				strconv.FormatFloat(m.UnitCost, 'f', 4, 64),
+				strconv.FormatFloat(m.TotalCost, 'f', 2, 64),
 				m.CreatedAt.Format("2006-01-02 15:04:05"),
+				strconv.FormatBool(m.Reversed),
+				optionalIntString(m.ReversalOfID),
 			})
 		}
 		writer.Flush()
@@ -310,3 +450,19 @@ func (c *StockMovementController) GetMonthlyReport(ctx *fiber.Ctx) error {
 		Data:   data,
 	})
 }
+
+// optionalFloatString mengubah *float64 menjadi string kosong jika nil (untuk kolom CSV)
+func optionalFloatString(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+// optionalIntString mengubah *int menjadi string kosong jika nil (untuk kolom CSV)
+func optionalIntString(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
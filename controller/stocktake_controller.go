@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StocktakeController struct {
+	Service service.StocktakeService
+}
+
+func NewStocktakeController(s service.StocktakeService) *StocktakeController {
+	return &StocktakeController{Service: s}
+}
+
+// FindAll godoc
+// @Summary Ambil semua sesi stock opname
+// @Description Mengambil seluruh sesi stock opname beserta hasil hitung dan selisihnya.
+// @Tags Stocktake
+// @Produce json
+// @Success 200 {object} web.WebResponse{data=[]web.StocktakeResponse}
+// @Failure 500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stocktakes [get]
+func (c *StocktakeController) FindAll(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindAll()
+	if err != nil {
+		return stocktakeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindById godoc
+// @Summary Ambil sesi stock opname berdasarkan ID
+// @Description Mengambil satu sesi stock opname beserta saldo sistem, hasil hitung, dan selisih per produk.
+// @Tags Stocktake
+// @Produce json
+// @Param id path int true "ID stock opname"
+// @Success 200 {object} web.WebResponse{data=web.StocktakeResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stocktakes/{id} [get]
+func (c *StocktakeController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.FindById(id)
+	if err != nil {
+		return stocktakeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Buka sesi stock opname
+// @Description Admin membuka sesi perhitungan fisik di satu gudang untuk daftar produk (product_ids) atau seluruh produk dalam satu kategori beserta subkategorinya (category_id). Produk serialized dan produk induk varian tidak ikut dihitung; yang dihitung adalah variannya.
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param request body web.StocktakeCreateRequest true "Data sesi stock opname"
+// @Success 201 {object} web.WebResponse{data=web.StocktakeResponse}
+// @Failure 400,401,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stocktakes [post]
+func (c *StocktakeController) Create(ctx *fiber.Ctx) error {
+	var req web.StocktakeCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Create(userID, req)
+	if err != nil {
+		return stocktakeErrorResponse(ctx, err)
+	}
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// SubmitCounts godoc
+// @Summary Kirim hasil hitung fisik
+// @Description Staff mengirim jumlah hasil hitung fisik per produk. Dapat dikirim berulang selama sesi masih open, hitungan terakhir yang dipakai. Saldo sistem saat itu disimpan sebagai dasar selisih.
+// @Tags Stocktake
+// @Accept json
+// @Produce json
+// @Param id path int true "ID stock opname"
+// @Param request body web.StocktakeCountRequest true "Hasil hitung per produk"
+// @Success 200 {object} web.WebResponse{data=web.StocktakeResponse}
+// @Failure 400,401,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stocktakes/{id}/counts [put]
+func (c *StocktakeController) SubmitCounts(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	var req web.StocktakeCountRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.SubmitCounts(id, userID, req)
+	if err != nil {
+		return stocktakeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Approve godoc
+// @Summary Setujui stock opname
+// @Description Admin menyetujui sesi stock opname. Selisih hasil hitung terhadap saldo sistem saat produk dihitung diposting sebagai pergerakan stok bertipe adjust dengan reference stocktake:{id}.
+// @Tags Stocktake
+// @Produce json
+// @Param id path int true "ID stock opname"
+// @Success 200 {object} web.WebResponse{data=web.StocktakeResponse}
+// @Failure 400,401,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stocktakes/{id}/approve [post]
+func (c *StocktakeController) Approve(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(http.StatusUnauthorized).JSON(web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Error:  "User ID not found in token",
+		})
+	}
+
+	result, err := c.Service.Approve(id, userID)
+	if err != nil {
+		return stocktakeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Cancel godoc
+// @Summary Batalkan stock opname
+// @Description Admin membatalkan sesi stock opname yang masih open tanpa memposting penyesuaian stok.
+// @Tags Stocktake
+// @Produce json
+// @Param id path int true "ID stock opname"
+// @Success 200 {object} web.WebResponse{data=web.StocktakeResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /stocktakes/{id}/cancel [post]
+func (c *StocktakeController) Cancel(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid ID",
+		})
+	}
+
+	result, err := c.Service.Cancel(id)
+	if err != nil {
+		return stocktakeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+func stocktakeErrorResponse(ctx *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case msg == "stocktake not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  msg,
+		})
+	case msg == "stocktake is not open", msg == "stocktake has uncounted lines":
+		return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+			Code:   http.StatusConflict,
+			Status: "CONFLICT",
+			Error:  msg,
+		})
+	case msg == "no product to count", msg == "product is not part of this stocktake",
+		msg == "serialized product cannot be counted in stocktake", msg == "parent product cannot be counted in stocktake", stockMovementBadRequests[msg]:
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  msg,
+		})
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  msg,
+		})
+	}
+}
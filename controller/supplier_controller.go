@@ -0,0 +1,403 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SupplierController struct {
+	Service service.SupplierService
+}
+
+func NewSupplierController(service service.SupplierService) *SupplierController {
+	return &SupplierController{Service: service}
+}
+
+// FindAll godoc
+// @Summary Mendapatkan semua supplier
+// @Description Mengambil semua data supplier beserta kontak, lead time, dan termin pembayaran
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} web.WebResponse{data=[]web.SupplierResponse}
+// @Failure 500 {object} web.WebResponse
+// @Router /suppliers [get]
+func (c *SupplierController) FindAll(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindAll()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindById godoc
+// @Summary Mendapatkan supplier berdasarkan ID
+// @Description Mengambil detail supplier berdasarkan ID yang diberikan
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Supplier"
+// @Success 200 {object} web.WebResponse{data=web.SupplierResponse}
+// @Failure 400,404 {object} web.WebResponse
+// @Router /suppliers/{id} [get]
+func (c *SupplierController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid supplier ID",
+		})
+	}
+
+	result, err := c.Service.FindById(id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  "Supplier not found",
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Membuat supplier baru
+// @Description Menambahkan supplier baru ke dalam sistem
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body web.SupplierCreateOrUpdateRequest true "Data supplier baru"
+// @Success 201 {object} web.WebResponse{data=web.SupplierResponse}
+// @Failure 400 {object} web.WebResponse
+// @Router /suppliers [post]
+func (c *SupplierController) Create(ctx *fiber.Ctx) error {
+	var req web.SupplierCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Create(req)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// Update godoc
+// @Summary Memperbarui supplier
+// @Description Mengubah data supplier berdasarkan ID
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Supplier"
+// @Param request body web.SupplierCreateOrUpdateRequest true "Data supplier yang diperbarui"
+// @Success 200 {object} web.WebResponse{data=web.SupplierResponse}
+// @Failure 400,404 {object} web.WebResponse
+// @Router /suppliers/{id} [put]
+func (c *SupplierController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid supplier ID",
+		})
+	}
+
+	var req web.SupplierCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Update(id, req)
+	if err != nil {
+		if err.Error() == "supplier not found" {
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Supplier not found",
+			})
+		}
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Delete godoc
+// @Summary Menghapus supplier
+// @Description Menghapus supplier berdasarkan ID. Supplier yang masih terhubung dengan produk tidak dapat dihapus.
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Supplier"
+// @Success 200 {object} web.WebResponse{data=string}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Router /suppliers/{id} [delete]
+func (c *SupplierController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid supplier ID",
+		})
+	}
+
+	err = c.Service.Delete(id)
+	if err != nil {
+		switch err.Error() {
+		case "supplier not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Supplier not found",
+			})
+		case "supplier is still in use":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		default:
+			return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+				Code:   http.StatusInternalServerError,
+				Status: "INTERNAL SERVER ERROR",
+				Error:  err.Error(),
+			})
+		}
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "Supplier deleted",
+	})
+}
+
+// FindProducts godoc
+// @Summary Mendapatkan produk yang dipasok supplier
+// @Description Mengambil daftar produk yang terhubung dengan supplier beserta SKU supplier, harga beli terakhir, dan status preferred
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Supplier"
+// @Success 200 {object} web.WebResponse{data=[]web.ProductSupplierResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /suppliers/{id}/products [get]
+func (c *SupplierController) FindProducts(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid supplier ID",
+		})
+	}
+
+	result, err := c.Service.FindProducts(id)
+	if err != nil {
+		return productSupplierErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindByProduct godoc
+// @Summary Mendapatkan supplier sebuah produk
+// @Description Mengambil daftar supplier yang bisa memasok produk, supplier preferred ditampilkan paling atas
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Success 200 {object} web.WebResponse{data=[]web.ProductSupplierResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/suppliers [get]
+func (c *SupplierController) FindByProduct(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	result, err := c.Service.FindByProduct(id)
+	if err != nil {
+		return productSupplierErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// LinkProduct godoc
+// @Summary Menghubungkan produk dengan supplier
+// @Description Membuat atau memperbarui hubungan produk-supplier (SKU supplier, harga beli terakhir, preferred). Menandai preferred akan melepas tanda preferred supplier lain untuk produk tersebut.
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param supplier_id path int true "ID Supplier"
+// @Param request body web.ProductSupplierRequest true "Data hubungan produk-supplier"
+// @Success 200 {object} web.WebResponse{data=web.ProductSupplierResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/suppliers/{supplier_id} [put]
+func (c *SupplierController) LinkProduct(ctx *fiber.Ctx) error {
+	productID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+	supplierID, err := strconv.Atoi(ctx.Params("supplier_id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid supplier ID",
+		})
+	}
+
+	var req web.ProductSupplierRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.LinkProduct(productID, supplierID, req)
+	if err != nil {
+		return productSupplierErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// UnlinkProduct godoc
+// @Summary Memutus hubungan produk dengan supplier
+// @Description Menghapus supplier dari daftar pemasok produk
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param supplier_id path int true "ID Supplier"
+// @Success 200 {object} web.WebResponse{data=string}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/suppliers/{supplier_id} [delete]
+func (c *SupplierController) UnlinkProduct(ctx *fiber.Ctx) error {
+	productID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+	supplierID, err := strconv.Atoi(ctx.Params("supplier_id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid supplier ID",
+		})
+	}
+
+	if err := c.Service.UnlinkProduct(productID, supplierID); err != nil {
+		return productSupplierErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "Product supplier removed",
+	})
+}
+
+func productSupplierErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "product not found", "supplier not found", "product supplier not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  err.Error(),
+		})
+	default:
+		if strings.HasPrefix(err.Error(), "validation error:") {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+}
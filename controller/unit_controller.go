@@ -0,0 +1,298 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UnitController struct {
+	Service service.UnitService
+}
+
+func NewUnitController(service service.UnitService) *UnitController {
+	return &UnitController{Service: service}
+}
+
+// FindAll godoc
+// @Summary Mendapatkan semua satuan
+// @Description Mengambil katalog satuan (unit of measure), misalnya pcs, box, kg
+// @Tags Units
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} web.WebResponse{data=[]web.UnitResponse}
+// @Failure 500 {object} web.WebResponse
+// @Router /units [get]
+func (c *UnitController) FindAll(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindAll()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Membuat satuan baru
+// @Description Menambahkan satuan ke katalog. Kode satuan harus unik.
+// @Tags Units
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body web.UnitCreateOrUpdateRequest true "Data satuan baru"
+// @Success 201 {object} web.WebResponse{data=web.UnitResponse}
+// @Failure 400 {object} web.WebResponse
+// @Router /units [post]
+func (c *UnitController) Create(ctx *fiber.Ctx) error {
+	var req web.UnitCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Create(req)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// Update godoc
+// @Summary Memperbarui satuan
+// @Description Mengubah kode atau nama satuan berdasarkan ID
+// @Tags Units
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Satuan"
+// @Param request body web.UnitCreateOrUpdateRequest true "Data satuan yang diperbarui"
+// @Success 200 {object} web.WebResponse{data=web.UnitResponse}
+// @Failure 400,404 {object} web.WebResponse
+// @Router /units/{id} [put]
+func (c *UnitController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid unit ID",
+		})
+	}
+
+	var req web.UnitCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Update(id, req)
+	if err != nil {
+		if err.Error() == "unit not found" {
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Unit not found",
+			})
+		}
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Delete godoc
+// @Summary Menghapus satuan
+// @Description Menghapus satuan berdasarkan ID. Satuan yang masih dipakai produk atau stock movement tidak dapat dihapus.
+// @Tags Units
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Satuan"
+// @Success 200 {object} web.WebResponse{data=string}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Router /units/{id} [delete]
+func (c *UnitController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid unit ID",
+		})
+	}
+
+	err = c.Service.Delete(id)
+	if err != nil {
+		switch err.Error() {
+		case "unit not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Unit not found",
+			})
+		case "unit is still in use":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		default:
+			return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+				Code:   http.StatusInternalServerError,
+				Status: "INTERNAL SERVER ERROR",
+				Error:  err.Error(),
+			})
+		}
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "Unit deleted",
+	})
+}
+
+// FindProductUnits godoc
+// @Summary Mendapatkan satuan sebuah produk
+// @Description Mengambil satuan dasar produk dan satuan alternatif beserta faktor konversinya
+// @Tags Units
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Success 200 {object} web.WebResponse{data=web.ProductUnitsResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Router /products/{id}/units [get]
+func (c *UnitController) FindProductUnits(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	result, err := c.Service.FindProductUnits(id)
+	if err != nil {
+		return productUnitErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// SetProductUnits godoc
+// @Summary Mengatur satuan sebuah produk
+// @Description Mengganti satuan dasar dan seluruh satuan alternatif produk. Factor adalah jumlah satuan dasar dalam satu satuan alternatif. Satuan dasar tidak bisa diganti setelah produk punya stock movement.
+// @Tags Units
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Produk"
+// @Param request body web.ProductUnitsRequest true "Satuan dasar dan satuan alternatif"
+// @Success 200 {object} web.WebResponse{data=web.ProductUnitsResponse}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Router /products/{id}/units [put]
+func (c *UnitController) SetProductUnits(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid product ID",
+		})
+	}
+
+	var req web.ProductUnitsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.SetProductUnits(id, req)
+	if err != nil {
+		return productUnitErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+func productUnitErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "product not found":
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  err.Error(),
+		})
+	case "base unit cannot be changed after stock movements exist":
+		return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+			Code:   http.StatusConflict,
+			Status: "CONFLICT",
+			Error:  err.Error(),
+		})
+	case "unit not found", "duplicate unit for product":
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	default:
+		if strings.HasPrefix(err.Error(), "validation error:") {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+}
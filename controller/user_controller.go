@@ -187,7 +187,7 @@ func (c *UserController) Update(ctx *fiber.Ctx) error {
 
 // Delete godoc
 // @Summary Hapus user berdasarkan ID
-// @Description Endpoint ini digunakan untuk menghapus user dari sistem berdasarkan ID-nya.
+// @Description Endpoint ini digunakan untuk menghapus user dari sistem berdasarkan ID-nya. User dipindahkan ke trash (soft delete) dan bisa dipulihkan.
 // @Tags User
 // @Produce json
 // @Param id path int true "ID user yang ingin dihapus"
@@ -220,3 +220,124 @@ func (c *UserController) Delete(ctx *fiber.Ctx) error {
 		Data:   "User deleted",
 	})
 }
+
+// FindTrashed godoc
+// @Summary Daftar user di trash
+// @Description Endpoint ini digunakan untuk mengambil user yang sudah dihapus (soft delete) dan masih bisa dipulihkan.
+// @Tags User
+// @Produce json
+// @Success 200 {object} web.WebResponse{data=[]web.UserResponse}
+// @Failure 500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /users/trash [get]
+func (c *UserController) FindTrashed(ctx *fiber.Ctx) error {
+	users, err := c.UserService.FindTrashed()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   users,
+	})
+}
+
+// Restore godoc
+// @Summary Pulihkan user dari trash
+// @Description Endpoint ini digunakan untuk memulihkan user yang sudah dihapus sehingga bisa login kembali.
+// @Tags User
+// @Produce json
+// @Param id path int true "ID user"
+// @Success 200 {object} web.WebResponse{data=web.UserResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /users/trash/{id}/restore [post]
+func (c *UserController) Restore(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid user ID",
+		})
+	}
+
+	user, err := c.UserService.Restore(id)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "User not found in trash",
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   user,
+	})
+}
+
+// Purge godoc
+// @Summary Hapus permanen user dari trash
+// @Description Endpoint ini digunakan untuk menghapus permanen user di trash. Ditolak (409) selama masih ada movement, dokumen, reservasi, order, atau stocktake yang dicatat atas namanya; jumlah rujukannya dikirim di data.
+// @Tags User
+// @Produce json
+// @Param id path int true "ID user"
+// @Success 200 {object} web.WebResponse{data=web.PurgeResponse}
+// @Failure 400,404,500 {object} web.WebResponse
+// @Failure 409 {object} web.WebResponse{data=web.PurgeResponse}
+// @Security BearerAuth
+// @Router /users/trash/{id} [delete]
+func (c *UserController) Purge(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid user ID",
+		})
+	}
+
+	result, err := c.UserService.Purge(id)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "User not found in trash",
+			})
+		case "user is still referenced":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Data:   result,
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ValuationController struct {
+	Service service.ValuationService
+}
+
+func NewValuationController(s service.ValuationService) *ValuationController {
+	return &ValuationController{Service: s}
+}
+
+// Valuation godoc
+// @Summary Laporan nilai persediaan
+// @Description Menghitung quantity, harga pokok per unit, dan total nilai persediaan per produk dan per kategori pada tanggal tertentu. Metode costing (fifo atau average) diatur lewat env COSTING_METHOD.
+// @Tags Report
+// @Produce json
+// @Param as_of query string false "Tanggal penilaian YYYY-MM-DD (default: hari ini)"
+// @Param category_id query int false "Filter berdasarkan ID kategori, termasuk seluruh subkategorinya"
+// @Success 200 {object} web.WebResponse{data=web.StockValuationResponse}
+// @Failure 400,500 {object} web.WebResponse
+// @Security BearerAuth
+// @Router /reports/valuation [get]
+func (c *ValuationController) Valuation(ctx *fiber.Ctx) error {
+	filters := map[string]interface{}{}
+	if categoryID := ctx.Query("category_id"); categoryID != "" {
+		id, err := strconv.Atoi(categoryID)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "invalid category_id",
+			})
+		}
+		filters["category_id"] = id
+	}
+
+	result, err := c.Service.Valuation(ctx.Query("as_of"), filters)
+	if err != nil {
+		if err.Error() == "invalid as_of date" {
+			return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  err.Error(),
+			})
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WarehouseController struct {
+	Service service.WarehouseService
+}
+
+func NewWarehouseController(service service.WarehouseService) *WarehouseController {
+	return &WarehouseController{Service: service}
+}
+
+// FindAll godoc
+// @Summary Mendapatkan semua gudang
+// @Description Mengambil semua data gudang (lokasi penyimpanan) yang tersedia
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} web.WebResponse{data=[]web.WarehouseResponse}
+// @Failure 500 {object} web.WebResponse
+// @Router /warehouses [get]
+func (c *WarehouseController) FindAll(ctx *fiber.Ctx) error {
+	result, err := c.Service.FindAll()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+			Code:   http.StatusInternalServerError,
+			Status: "INTERNAL SERVER ERROR",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// FindById godoc
+// @Summary Mendapatkan gudang berdasarkan ID
+// @Description Mengambil detail gudang berdasarkan ID yang diberikan
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Gudang"
+// @Success 200 {object} web.WebResponse{data=web.WarehouseResponse}
+// @Failure 400,404 {object} web.WebResponse
+// @Router /warehouses/{id} [get]
+func (c *WarehouseController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid warehouse ID",
+		})
+	}
+
+	result, err := c.Service.FindById(id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+			Code:   http.StatusNotFound,
+			Status: "NOT FOUND",
+			Error:  "Warehouse not found",
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Create godoc
+// @Summary Membuat gudang baru
+// @Description Menambahkan gudang baru ke dalam sistem
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body web.WarehouseCreateOrUpdateRequest true "Data gudang baru"
+// @Success 201 {object} web.WebResponse{data=web.WarehouseResponse}
+// @Failure 400 {object} web.WebResponse
+// @Router /warehouses [post]
+func (c *WarehouseController) Create(ctx *fiber.Ctx) error {
+	var req web.WarehouseCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Create(req)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   result,
+	})
+}
+
+// Update godoc
+// @Summary Memperbarui gudang
+// @Description Mengubah data gudang berdasarkan ID
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Gudang"
+// @Param request body web.WarehouseCreateOrUpdateRequest true "Data gudang yang diperbarui"
+// @Success 200 {object} web.WebResponse{data=web.WarehouseResponse}
+// @Failure 400,404 {object} web.WebResponse
+// @Router /warehouses/{id} [put]
+func (c *WarehouseController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid warehouse ID",
+		})
+	}
+
+	var req web.WarehouseCreateOrUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid request body",
+		})
+	}
+
+	result, err := c.Service.Update(id, req)
+	if err != nil {
+		if err.Error() == "warehouse not found" {
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Warehouse not found",
+			})
+		}
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  err.Error(),
+		})
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	})
+}
+
+// Delete godoc
+// @Summary Menghapus gudang
+// @Description Menghapus gudang berdasarkan ID. Gudang yang masih memiliki stok atau riwayat pergerakan stok tidak dapat dihapus.
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID Gudang"
+// @Success 200 {object} web.WebResponse{data=string}
+// @Failure 400,404,409,500 {object} web.WebResponse
+// @Router /warehouses/{id} [delete]
+func (c *WarehouseController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Error:  "Invalid warehouse ID",
+		})
+	}
+
+	err = c.Service.Delete(id)
+	if err != nil {
+		switch err.Error() {
+		case "warehouse not found":
+			return ctx.Status(http.StatusNotFound).JSON(web.WebResponse{
+				Code:   http.StatusNotFound,
+				Status: "NOT FOUND",
+				Error:  "Warehouse not found",
+			})
+		case "warehouse is still in use":
+			return ctx.Status(http.StatusConflict).JSON(web.WebResponse{
+				Code:   http.StatusConflict,
+				Status: "CONFLICT",
+				Error:  err.Error(),
+			})
+		default:
+			return ctx.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+				Code:   http.StatusInternalServerError,
+				Status: "INTERNAL SERVER ERROR",
+				Error:  err.Error(),
+			})
+		}
+	}
+
+	return ctx.JSON(web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "Warehouse deleted",
+	})
+}
@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"image"
+	"image/color"
+)
+
+// Thumbnail memperkecil img agar sisi terpanjangnya paling besar maxSize piksel dengan rasio
+// yang sama; gambar yang sudah kecil tidak diperbesar. Setiap piksel thumbnail adalah
+// rata-rata piksel sumber yang tercakup (box filter), dan bagian transparan diberi latar
+// putih karena thumbnail disimpan sebagai JPEG.
+func Thumbnail(img image.Image, maxSize int) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	tw, th := w, h
+	if w > maxSize || h > maxSize {
+		if w >= h {
+			tw, th = maxSize, max(h*maxSize/w, 1)
+		} else {
+			tw, th = max(w*maxSize/h, 1), maxSize
+		}
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0 := bounds.Min.Y + y*h/th
+		y1 := max(bounds.Min.Y+(y+1)*h/th, y0+1)
+		for x := 0; x < tw; x++ {
+			x0 := bounds.Min.X + x*w/tw
+			x1 := max(bounds.Min.X+(x+1)*w/tw, x0+1)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// Nilai RGBA() sudah premultiplied, komposit di atas putih cukup
+					// menambahkan bagian yang tidak tertutup alpha
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					b += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+			thumb.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return thumb
+}
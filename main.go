@@ -22,12 +22,14 @@ import (
 	"inventory-management-api/app"
 	"inventory-management-api/config"
 	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
 	"inventory-management-api/repository"
 	"inventory-management-api/route"
 	"inventory-management-api/service"
 
 	"log"
 	"os"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -52,28 +54,127 @@ func main() {
 		log.Fatalf("❌ Gagal konek database: %v", err)
 	}
 
+	// Sinkronisasi skema database
+	if err := config.AutoMigrate(db); err != nil {
+		log.Fatalf("❌ Gagal migrasi database: %v", err)
+	}
+
 	// Inisialisasi validator
 	validate := validator.New()
 
+	// Metode penilaian persediaan (fifo atau average)
+	costingMethod := config.CostingMethod()
+
+	// Penyimpanan berkas lampiran produk
+	fileStorage := config.NewStorage()
+
 	// Inisialisasi repository
 	userRepo := repository.NewUserRepository(db)
 	categoryRepo := repository.NewCategoryRepository(db)
 	productRepo := repository.NewProductRepository(db)
 	stockMovementRepo := repository.NewStockMovementRepository(db)
+	warehouseRepo := repository.NewWarehouseRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+	productStockRepo := repository.NewProductStockRepository(db)
+	stockLotRepo := repository.NewStockLotRepository(db)
+	serialNumberRepo := repository.NewSerialNumberRepository(db)
+	stocktakeRepo := repository.NewStocktakeRepository(db)
+	stockDocumentRepo := repository.NewStockDocumentRepository(db)
+	idempotencyKeyRepo := repository.NewIdempotencyKeyRepository(db)
+	reservationRepo := repository.NewReservationRepository(db)
+	stockAlertRepo := repository.NewStockAlertRepository(db)
+	costLayerRepo := repository.NewCostLayerRepository(db)
+	stockSnapshotRepo := repository.NewStockSnapshotRepository(db)
+	unitRepo := repository.NewUnitRepository(db)
+	productBarcodeRepo := repository.NewProductBarcodeRepository(db)
+	kitRepo := repository.NewKitRepository(db)
+	productAttachmentRepo := repository.NewProductAttachmentRepository(db)
+	purchaseOrderRepo := repository.NewPurchaseOrderRepository(db)
+	salesOrderRepo := repository.NewSalesOrderRepository(db)
 
 	// Inisialisasi service
 	authService := service.NewAuthService(userRepo)
 	userService := service.NewUserService(userRepo, validate)
-	categoryService := service.NewCategoryService(categoryRepo, validate)
-	productService := service.NewProductService(productRepo, validate)
-	stockMovementService := service.NewStockMovementService(stockMovementRepo, productRepo, db, validate)
+	categoryService := service.NewCategoryService(categoryRepo, productRepo, kitRepo, validate)
+	productService := service.NewProductService(productRepo, productStockRepo, reservationRepo, stockAlertRepo, unitRepo, categoryRepo, kitRepo, serialNumberRepo, fileStorage, validate)
+	warehouseService := service.NewWarehouseService(warehouseRepo, validate)
+	supplierService := service.NewSupplierService(supplierRepo, productRepo, validate)
+	unitService := service.NewUnitService(unitRepo, productRepo, stockMovementRepo, validate)
+	productBarcodeService := service.NewProductBarcodeService(productBarcodeRepo, productRepo, validate)
+	productAttachmentService := service.NewProductAttachmentService(productAttachmentRepo, productRepo, fileStorage, config.MaxUploadSize())
+	stockMovementService := service.NewStockMovementService(stockMovementRepo, productRepo, productStockRepo, warehouseRepo, stockLotRepo, serialNumberRepo, reservationRepo, stockAlertRepo, costLayerRepo, unitRepo, kitRepo, costingMethod, db, validate)
+	stockLotService := service.NewStockLotService(stockLotRepo)
+	serialNumberService := service.NewSerialNumberService(serialNumberRepo, stockMovementRepo)
+	stocktakeService := service.NewStocktakeService(stocktakeRepo, productRepo, productStockRepo, warehouseRepo, stockMovementService, db, validate)
+	reservationService := service.NewReservationService(reservationRepo, productRepo, productStockRepo, warehouseRepo, db, validate)
+	purchaseOrderService := service.NewPurchaseOrderService(purchaseOrderRepo, stockMovementRepo, productRepo, warehouseRepo, stockMovementService, db, validate)
+	stockAlertService := service.NewStockAlertService(stockAlertRepo)
+	valuationService := service.NewValuationService(stockMovementRepo, productRepo, categoryRepo, costingMethod)
+	stockBalanceService := service.NewStockBalanceService(stockMovementRepo, stockSnapshotRepo, productRepo, productStockRepo, warehouseRepo, unitRepo)
+	reconciliationService := service.NewReconciliationService(stockMovementRepo, productRepo, productStockRepo, warehouseRepo, stockMovementService, db)
+	replenishmentService := service.NewReplenishmentService(productRepo, stockMovementRepo, reservationRepo, purchaseOrderRepo, supplierRepo, validate)
+	salesOrderService := service.NewSalesOrderService(salesOrderRepo, stockMovementRepo, productRepo, warehouseRepo, reservationRepo, stockMovementService, reservationService, db, validate)
+	idempotencyService := service.NewIdempotencyService(idempotencyKeyRepo, config.IdempotencyKeyTTL())
+	kitService := service.NewKitService(kitRepo, productRepo, productStockRepo, reservationRepo, warehouseRepo, stockMovementRepo, stockMovementService, db, validate)
+	stockDocumentService := service.NewStockDocumentService(stockDocumentRepo, productRepo, productStockRepo, warehouseRepo, reservationRepo, kitRepo, stockMovementService, db, validate)
+
+	// Mode command-line: `<binary> reconcile [-repair] [-user-id N]`, tidak menjalankan server
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		os.Exit(runReconcile(reconciliationService, userRepo, os.Args[2:]))
+	}
 
 	// Inisialisasi controller
 	authController := controller.NewAuthController(authService, userService)
 	userController := controller.NewUserController(userService)
 	categoryController := controller.NewCategoryController(categoryService)
 	productController := controller.NewProductController(productService)
+	warehouseController := controller.NewWarehouseController(warehouseService)
+	supplierController := controller.NewSupplierController(supplierService)
+	unitController := controller.NewUnitController(unitService)
+	kitController := controller.NewKitController(kitService)
+	productBarcodeController := controller.NewProductBarcodeController(productBarcodeService)
+	productAttachmentController := controller.NewProductAttachmentController(productAttachmentService)
 	stockMovementController := controller.NewStockMovementController(stockMovementService)
+	stockLotController := controller.NewStockLotController(stockLotService)
+	serialNumberController := controller.NewSerialNumberController(serialNumberService)
+	stocktakeController := controller.NewStocktakeController(stocktakeService)
+	stockDocumentController := controller.NewStockDocumentController(stockDocumentService)
+	reservationController := controller.NewReservationController(reservationService)
+	purchaseOrderController := controller.NewPurchaseOrderController(purchaseOrderService)
+	salesOrderController := controller.NewSalesOrderController(salesOrderService)
+	stockAlertController := controller.NewStockAlertController(stockAlertService)
+	replenishmentController := controller.NewReplenishmentController(replenishmentService)
+	valuationController := controller.NewValuationController(valuationService)
+	stockBalanceController := controller.NewStockBalanceController(stockBalanceService)
+	reconciliationController := controller.NewReconciliationController(reconciliationService)
+
+	// Tandai reservasi yang sudah lewat expires_at dan bersihkan Idempotency-Key kedaluwarsa setiap menit
+	go func() {
+		for range time.Tick(time.Minute) {
+			if _, err := reservationService.ReleaseExpired(); err != nil {
+				log.Printf("[WARNING] Gagal melepas reservasi kedaluwarsa: %v", err)
+			}
+			if _, err := idempotencyService.DeleteExpired(); err != nil {
+				log.Printf("[WARNING] Gagal menghapus Idempotency-Key kedaluwarsa: %v", err)
+			}
+		}
+	}()
+
+	// Simpan snapshot saldo stok hari kemarin (sekali per hari) untuk mempercepat query saldo historis
+	go func() {
+		taken := ""
+		for {
+			yesterday := time.Now().AddDate(0, 0, -1)
+			if day := yesterday.Format("2006-01-02"); day != taken {
+				if err := stockBalanceService.TakeSnapshot(yesterday); err != nil {
+					log.Printf("[WARNING] Gagal menyimpan snapshot saldo stok %s: %v", day, err)
+				} else {
+					taken = day
+				}
+			}
+			time.Sleep(time.Hour)
+		}
+	}()
 
 	// Inisialisasi Fiber app
 	fiberApp := app.NewApp()
@@ -81,18 +182,39 @@ func main() {
 	// Aktifkan CORS untuk semua origin (bisa dibatasi jika sudah production)
 	fiberApp.Use(cors.New(cors.Config{
 		AllowOrigins: "*", // Development mode, menerima semua akses (untuk Portfolio)
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, Idempotency-Key",
 	}))
 
 	// Endpoint Swagger UI
 	fiberApp.Get("/swagger/*", swagger.FiberWrapHandler())
 
+	// Middleware Idempotency-Key untuk endpoint POST yang mengubah data
+	idempotent := middleware.Idempotency(idempotencyService)
+
 	// Registrasi semua routes
 	route.RegisterAuthRoutes(fiberApp, authController)
-	route.RegisterUserRoutes(fiberApp, userController)
-	route.RegisterCategoryRoutes(fiberApp, categoryController)
-	route.RegisterProductRoutes(fiberApp, productController)
-	route.RegisterStockMovementRoutes(fiberApp, stockMovementController)
+	route.RegisterUserRoutes(fiberApp, userController, idempotent)
+	route.RegisterCategoryRoutes(fiberApp, categoryController, idempotent)
+	route.RegisterProductRoutes(fiberApp, productController, idempotent)
+	route.RegisterWarehouseRoutes(fiberApp, warehouseController, idempotent)
+	route.RegisterSupplierRoutes(fiberApp, supplierController, idempotent)
+	route.RegisterUnitRoutes(fiberApp, unitController, idempotent)
+	route.RegisterProductBarcodeRoutes(fiberApp, productBarcodeController, idempotent)
+	route.RegisterProductAttachmentRoutes(fiberApp, productAttachmentController, idempotent)
+	route.RegisterKitRoutes(fiberApp, kitController, idempotent)
+	route.RegisterStockMovementRoutes(fiberApp, stockMovementController, idempotent)
+	route.RegisterStockLotRoutes(fiberApp, stockLotController)
+	route.RegisterSerialNumberRoutes(fiberApp, serialNumberController)
+	route.RegisterStocktakeRoutes(fiberApp, stocktakeController, idempotent)
+	route.RegisterStockDocumentRoutes(fiberApp, stockDocumentController, idempotent)
+	route.RegisterReservationRoutes(fiberApp, reservationController, idempotent)
+	route.RegisterPurchaseOrderRoutes(fiberApp, purchaseOrderController, idempotent)
+	route.RegisterSalesOrderRoutes(fiberApp, salesOrderController, idempotent)
+	route.RegisterStockAlertRoutes(fiberApp, stockAlertController)
+	route.RegisterReplenishmentRoutes(fiberApp, replenishmentController)
+	route.RegisterValuationRoutes(fiberApp, valuationController)
+	route.RegisterStockBalanceRoutes(fiberApp, stockBalanceController)
+	route.RegisterReconciliationRoutes(fiberApp, reconciliationController, idempotent)
 
 	// Jalankan server
 	port := os.Getenv("PORT")
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Idempotency membuat handler POST (dan DELETE yang memposting transaksi) aman untuk di-retry. Request dengan header
+// Idempotency-Key yang sama (per user) hanya diproses sekali; retry berikutnya menerima
+// response yang tersimpan dengan header Idempotent-Replayed: true. Request tanpa header
+// diproses seperti biasa. Harus dipasang setelah JWTMiddleware.
+func Idempotency(s service.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" || (c.Method() != fiber.MethodPost && c.Method() != fiber.MethodDelete) {
+			return c.Next()
+		}
+		if len(key) > 255 {
+			return c.Status(http.StatusBadRequest).JSON(web.WebResponse{
+				Code:   http.StatusBadRequest,
+				Status: "BAD REQUEST",
+				Error:  "Idempotency-Key must be at most 255 characters",
+			})
+		}
+
+		userID, _ := c.Locals("user_id").(int)
+		record, replay, err := s.Begin(userID, key, c.Method(), c.Path(), c.Body())
+		if err != nil {
+			switch err.Error() {
+			case "idempotency key reused with different request":
+				return c.Status(http.StatusUnprocessableEntity).JSON(web.WebResponse{
+					Code:   http.StatusUnprocessableEntity,
+					Status: "UNPROCESSABLE ENTITY",
+					Error:  err.Error(),
+				})
+			case "idempotency key is still being processed":
+				return c.Status(http.StatusConflict).JSON(web.WebResponse{
+					Code:   http.StatusConflict,
+					Status: "CONFLICT",
+					Error:  err.Error(),
+				})
+			default:
+				return c.Status(http.StatusInternalServerError).JSON(web.WebResponse{
+					Code:   http.StatusInternalServerError,
+					Status: "INTERNAL SERVER ERROR",
+					Error:  err.Error(),
+				})
+			}
+		}
+
+		if replay {
+			c.Set("Idempotent-Replayed", "true")
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Status(record.StatusCode).SendString(record.ResponseBody)
+		}
+
+		if err := c.Next(); err != nil {
+			_ = s.Release(record.ID)
+			return err
+		}
+
+		// Error server tidak disimpan agar retry berikutnya diproses ulang
+		status := c.Response().StatusCode()
+		if status >= http.StatusInternalServerError {
+			_ = s.Release(record.ID)
+			return nil
+		}
+		if err := s.Complete(record.ID, status, c.Response().Body()); err != nil {
+			_ = s.Release(record.ID)
+		}
+		return nil
+	}
+}
@@ -1,9 +1,19 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Category struct {
-	ID        int    `gorm:"primaryKey"`
-	Name      string `gorm:"type:varchar(100);unique"`
+	ID   int    `gorm:"primaryKey"`
+	Name string `gorm:"type:varchar(100);unique"`
+
+	// ParentID menunjuk kategori induk (misalnya department > group > subgroup), NULL untuk kategori teratas
+	ParentID  *int `gorm:"index"`
 	CreatedAt time.Time
+
+	// DeletedAt diisi saat kategori dihapus (soft delete). Kategori di trash bisa dipulihkan.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// CategoryDeletion mencatat penghapusan kategori beserta mode yang dipilih admin: refuse
+// (hanya jika kategori kosong), reassign (produk dan subkategori dipindah ke
+// TargetCategoryID), atau cascade (produk ikut dihapus secara soft delete).
+type CategoryDeletion struct {
+	ID               int    `gorm:"primaryKey"`
+	CategoryID       int    `gorm:"not null;index"`
+	CategoryName     string `gorm:"type:varchar(100);not null"`
+	Mode             string `gorm:"type:enum('refuse','reassign','cascade');not null"`
+	TargetCategoryID *int
+	ProductCount     int `gorm:"not null;default:0"`
+	SubcategoryCount int `gorm:"not null;default:0"`
+	UserID           int `gorm:"not null"`
+	CreatedAt        time.Time
+}
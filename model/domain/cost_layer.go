@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// CostLayer adalah satu lapis harga pokok dari movement masuk. Movement keluar mengambil
+// Remaining dari layer paling lama terlebih dahulu (FIFO). Layer dicatat per produk,
+// bukan per gudang, karena transfer antar gudang tidak mengubah nilai persediaan.
+type CostLayer struct {
+	ID         int     `gorm:"primaryKey"`
+	ProductID  int     `gorm:"not null;index"`
+	MovementID int     `gorm:"not null;index"`
+	Quantity   int     `gorm:"not null"`
+	Remaining  int     `gorm:"not null"`
+	UnitCost   float64 `gorm:"type:decimal(15,4);not null"`
+	CreatedAt  time.Time
+}
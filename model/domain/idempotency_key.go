@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// IdempotencyKey menyimpan hasil request POST yang dikirim dengan header Idempotency-Key
+// agar retry dengan key yang sama dibalas ulang tanpa diproses dua kali.
+// StatusCode 0 berarti request pertama masih diproses.
+type IdempotencyKey struct {
+	ID           int       `gorm:"primaryKey"`
+	UserID       int       `gorm:"not null;uniqueIndex:idx_user_idempotency_key"`
+	Key          string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_idempotency_key"`
+	Method       string    `gorm:"type:varchar(10);not null"`
+	Path         string    `gorm:"type:varchar(255);not null"`
+	Fingerprint  string    `gorm:"type:char(64);not null"`
+	StatusCode   int       `gorm:"not null;default:0"`
+	ResponseBody string    `gorm:"type:mediumtext"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// KitComponent adalah satu baris bill of materials sebuah kit: setiap unit kit KitID
+// terdiri dari Quantity unit produk ComponentID. Produk yang punya komponen disebut kit.
+type KitComponent struct {
+	ID          int `gorm:"primaryKey"`
+	KitID       int `gorm:"not null;uniqueIndex:idx_kit_component"`
+	ComponentID int `gorm:"not null;uniqueIndex:idx_kit_component;index"`
+	Quantity    int `gorm:"not null"`
+
+	Component Product `gorm:"foreignKey:ComponentID"`
+}
+
+// KitAssembly mencatat perakitan Quantity kit di sebuah gudang. Movement out komponen dan
+// movement in kit-nya diberi Reference "kit-assembly:<id>". Automatic bernilai true jika
+// perakitan dilakukan otomatis oleh movement out kit yang stok rakitannya kurang.
+type KitAssembly struct {
+	ID          int  `gorm:"primaryKey"`
+	KitID       int  `gorm:"not null;index"`
+	WarehouseID int  `gorm:"not null"`
+	Quantity    int  `gorm:"not null"`
+	Automatic   bool `gorm:"not null;default:false"`
+	UserID      int
+	Note        string
+	CreatedAt   time.Time
+}
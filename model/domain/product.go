@@ -1,13 +1,51 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Product struct {
 	ID         int    `gorm:"primaryKey"`
 	Name       string `gorm:"type:varchar(100)"`
 	CategoryID int
 	Stock      int
-	CreatedAt  time.Time
+	Serialized bool `gorm:"not null;default:false"`
+	// SKU unik per produk, NULL jika belum diisi
+	SKU *string `gorm:"type:varchar(64);uniqueIndex"`
+
+	// ParentID diisi pada varian dan menunjuk ke produk induk. Produk induk tidak punya
+	// stok sendiri, stoknya adalah jumlah stok seluruh variannya.
+	ParentID *int `gorm:"index"`
+
+	// BaseUnitID adalah satuan dasar produk. Stock dan Quantity movement selalu dalam satuan ini.
+	BaseUnitID *int
+
+	// MinStock adalah batas stok minimum, ReorderPoint titik pemesanan ulang, dan
+	// ReorderQuantity jumlah yang disarankan saat memesan. Nilai 0 berarti tidak diatur.
+	MinStock        int `gorm:"not null;default:0"`
+	ReorderPoint    int `gorm:"not null;default:0"`
+	ReorderQuantity int `gorm:"not null;default:0"`
+
+	// AverageCost adalah moving weighted average harga pokok per unit
+	AverageCost float64 `gorm:"type:decimal(15,4);not null;default:0"`
+
+	CreatedAt time.Time
+
+	// DeletedAt diisi saat produk dihapus (soft delete), termasuk saat ikut terhapus bersama
+	// kategorinya. Produk di trash bisa dipulihkan.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Category Category      `gorm:"foreignKey:CategoryID"`
+	BaseUnit *Unit         `gorm:"foreignKey:BaseUnitID"`
+	Units    []ProductUnit `gorm:"foreignKey:ProductID"`
+
+	Attributes []ProductAttribute `gorm:"foreignKey:ProductID"`
+	Barcodes   []ProductBarcode   `gorm:"foreignKey:ProductID"`
+
+	// Components adalah bill of materials jika produk ini sebuah kit
+	Components []KitComponent `gorm:"foreignKey:KitID"`
 
-	Category Category `gorm:"foreignKey:CategoryID"`
+	Attachments []ProductAttachment `gorm:"foreignKey:ProductID"`
 }
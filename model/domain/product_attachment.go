@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// ProductAttachment adalah berkas milik produk: gambar (Kind "image") atau dokumen seperti
+// datasheet dan MSDS (Kind "document"). Hanya StorageKey yang disimpan; URL unduhan dibentuk
+// saat membuat response. Gambar juga punya thumbnail JPEG.
+type ProductAttachment struct {
+	ID          int    `gorm:"primaryKey"`
+	ProductID   int    `gorm:"not null;index"`
+	Kind        string `gorm:"type:enum('image','document');not null"`
+	FileName    string `gorm:"type:varchar(255);not null"`
+	ContentType string `gorm:"type:varchar(100);not null"`
+	Size        int64  `gorm:"not null"`
+	StorageKey  string `gorm:"type:varchar(255);not null"`
+
+	// Hanya untuk gambar: ukuran asli dan thumbnail-nya
+	Width        int    `gorm:"not null;default:0"`
+	Height       int    `gorm:"not null;default:0"`
+	ThumbnailKey string `gorm:"type:varchar(255);not null;default:''"`
+
+	UserID    int
+	CreatedAt time.Time
+}
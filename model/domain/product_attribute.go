@@ -0,0 +1,10 @@
+package domain
+
+// ProductAttribute adalah nilai atribut sebuah varian produk, misalnya size=M atau color=Red.
+// Kombinasi atribut membedakan varian dari varian lain dengan produk induk yang sama.
+type ProductAttribute struct {
+	ID        int    `gorm:"primaryKey"`
+	ProductID int    `gorm:"not null;uniqueIndex:idx_product_attribute"`
+	Name      string `gorm:"type:varchar(50);not null;uniqueIndex:idx_product_attribute;index:idx_attribute_value"`
+	Value     string `gorm:"type:varchar(100);not null;index:idx_attribute_value"`
+}
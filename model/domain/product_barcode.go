@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// ProductBarcode adalah barcode yang menunjuk ke sebuah produk. Satu produk boleh punya
+// beberapa barcode (misalnya EAN-13 dari pabrik dan Code128 internal).
+type ProductBarcode struct {
+	ID        int    `gorm:"primaryKey"`
+	ProductID int    `gorm:"not null;index"`
+	Code      string `gorm:"type:varchar(64);not null;unique"`
+	Symbology string `gorm:"type:enum('ean13','upca','code128');not null"`
+	CreatedAt time.Time
+}
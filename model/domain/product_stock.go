@@ -0,0 +1,13 @@
+package domain
+
+// ProductStock menyimpan saldo stok satu produk di satu gudang.
+// Product.Stock adalah total dari seluruh ProductStock milik produk tersebut.
+type ProductStock struct {
+	ID          int `gorm:"primaryKey"`
+	ProductID   int `gorm:"uniqueIndex:idx_product_warehouse"`
+	WarehouseID int `gorm:"uniqueIndex:idx_product_warehouse"`
+	Stock       int
+
+	Product   Product   `gorm:"foreignKey:ProductID"`
+	Warehouse Warehouse `gorm:"foreignKey:WarehouseID"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// PurchaseOrder adalah pesanan pembelian ke supplier. Penerimaan barang terhadap baris PO
+// memposting movement "in" dengan Reference "purchase-order:<id>".
+type PurchaseOrder struct {
+	ID           int    `gorm:"primaryKey"`
+	Supplier     string `gorm:"type:varchar(150);not null"`
+	WarehouseID  int
+	Status       string     `gorm:"type:enum('draft','ordered','partially_received','received','cancelled');default:'draft';index"`
+	ExpectedDate *time.Time `gorm:"type:date"`
+	Note         string
+	CreatedBy    int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	Lines     []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID"`
+	Warehouse Warehouse           `gorm:"foreignKey:WarehouseID"`
+}
+
+type PurchaseOrderLine struct {
+	ID               int `gorm:"primaryKey"`
+	PurchaseOrderID  int `gorm:"index"`
+	ProductID        int
+	OrderedQuantity  int
+	ReceivedQuantity int     `gorm:"not null;default:0"`
+	UnitCost         float64 `gorm:"type:decimal(15,4);not null;default:0"`
+	Note             string
+
+	Product Product `gorm:"foreignKey:ProductID"`
+}
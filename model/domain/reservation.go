@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Reservation menahan stok di satu gudang untuk kebutuhan yang belum dikirim. Stok fisik
+// (on hand) tidak berubah, tetapi jumlah yang bisa dipakai (available) berkurang.
+// Quantity adalah sisa yang masih ditahan, ReservedQuantity jumlah awalnya.
+type Reservation struct {
+	ID               int        `gorm:"primaryKey"`
+	ProductID        int        `gorm:"not null;index"`
+	WarehouseID      int        `gorm:"not null;index"`
+	Quantity         int        `gorm:"not null"`
+	ReservedQuantity int        `gorm:"not null"`
+	Owner            string     `gorm:"type:varchar(100);not null"`
+	Note             string     `gorm:"type:text"`
+	Status           string     `gorm:"type:enum('active','consumed','released','expired');not null;default:'active';index"`
+	ExpiresAt        *time.Time `gorm:"index"`
+	CreatedBy        int        `gorm:"not null"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime"`
+
+	Product   Product   `gorm:"foreignKey:ProductID"`
+	Warehouse Warehouse `gorm:"foreignKey:WarehouseID"`
+}
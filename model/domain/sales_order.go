@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// SalesOrder adalah permintaan barang keluar dari customer atau departemen. Saat dikonfirmasi,
+// stok yang tersedia ditahan lewat Reservation; pengiriman memposting movement "out" dengan
+// Reference "sales-order:<id>".
+type SalesOrder struct {
+	ID          int    `gorm:"primaryKey"`
+	Customer    string `gorm:"type:varchar(150);not null"`
+	WarehouseID int
+	Status      string `gorm:"type:enum('draft','confirmed','picked','shipped','cancelled');default:'draft';index"`
+	Note        string
+	CreatedBy   int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Lines     []SalesOrderLine `gorm:"foreignKey:SalesOrderID"`
+	Warehouse Warehouse        `gorm:"foreignKey:WarehouseID"`
+}
+
+// SalesOrderLine menyimpan jumlah dipesan dan terkirim. ReservationID menunjuk reservasi
+// yang menahan stok untuk sisa baris ini; sisa yang tidak tertahan adalah backorder.
+type SalesOrderLine struct {
+	ID              int `gorm:"primaryKey"`
+	SalesOrderID    int `gorm:"index"`
+	ProductID       int
+	OrderedQuantity int
+	ShippedQuantity int `gorm:"not null;default:0"`
+	ReservationID   *int
+	Note            string
+
+	Product     Product      `gorm:"foreignKey:ProductID"`
+	Reservation *Reservation `gorm:"foreignKey:ReservationID"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// SerialNumber mewakili satu unit fisik dari produk yang bertipe serialized.
+type SerialNumber struct {
+	ID          int    `gorm:"primaryKey"`
+	ProductID   int    `gorm:"index"`
+	Serial      string `gorm:"type:varchar(100);unique"`
+	WarehouseID int
+	Status      string `gorm:"type:enum('in_stock','out')"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Product   Product   `gorm:"foreignKey:ProductID"`
+	Warehouse Warehouse `gorm:"foreignKey:WarehouseID"`
+}
+
+// StockMovementSerial mencatat unit (serial number) mana saja yang ikut dalam sebuah movement.
+type StockMovementSerial struct {
+	ID              int `gorm:"primaryKey"`
+	StockMovementID int `gorm:"index"`
+	SerialNumberID  int `gorm:"index"`
+
+	SerialNumber SerialNumber `gorm:"foreignKey:SerialNumberID"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// StockAlert dicatat saat movement membuat stok produk turun di bawah ambang batasnya.
+// Selama alert masih open, produk yang sama tidak dibuatkan alert baru. Alert otomatis
+// resolved ketika movement berikutnya mengembalikan stok ke ambang batas atau lebih.
+type StockAlert struct {
+	ID                 int       `gorm:"primaryKey"`
+	ProductID          int       `gorm:"not null;index"`
+	Status             string    `gorm:"type:enum('open','resolved');not null;default:'open';index"`
+	Stock              int       `gorm:"not null"`
+	Threshold          int       `gorm:"not null"`
+	MovementID         *int      `gorm:"index"`
+	ResolvedMovementID *int      `gorm:"index"`
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	ResolvedAt         *time.Time
+
+	Product Product `gorm:"foreignKey:ProductID"`
+}
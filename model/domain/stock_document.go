@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// StockDocument adalah header dokumen (misalnya surat jalan) yang berisi banyak movement.
+// Seluruh movement dalam satu dokumen diposting dalam satu transaksi.
+type StockDocument struct {
+	ID              int    `gorm:"primaryKey"`
+	Type            string `gorm:"type:enum('in','out','transfer')"`
+	ReferenceNumber string `gorm:"type:varchar(100);index"`
+	WarehouseID     int
+	ToWarehouseID   *int
+	Note            string
+	UserID          int
+	CreatedAt       time.Time
+
+	Movements []StockMovement `gorm:"foreignKey:DocumentID"`
+	User      User            `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// StockLot menyimpan saldo satu lot/batch produk di satu gudang beserta tanggal kedaluwarsanya.
+type StockLot struct {
+	ID          int        `gorm:"primaryKey"`
+	ProductID   int        `gorm:"uniqueIndex:idx_product_warehouse_lot"`
+	WarehouseID int        `gorm:"uniqueIndex:idx_product_warehouse_lot"`
+	LotNumber   string     `gorm:"type:varchar(100);uniqueIndex:idx_product_warehouse_lot"`
+	ExpiryDate  *time.Time `gorm:"type:date"`
+	Stock       int
+	CreatedAt   time.Time
+
+	Product   Product   `gorm:"foreignKey:ProductID"`
+	Warehouse Warehouse `gorm:"foreignKey:WarehouseID"`
+}
+
+// StockMovementLot mencatat berapa kuantitas sebuah movement yang diambil dari / masuk ke lot tertentu.
+type StockMovementLot struct {
+	ID              int `gorm:"primaryKey"`
+	StockMovementID int `gorm:"index"`
+	LotID           int
+	Quantity        int
+
+	Lot StockLot `gorm:"foreignKey:LotID"`
+}
@@ -3,14 +3,53 @@ package domain
 import "time"
 
 type StockMovement struct {
-	ID        int `gorm:"primaryKey"`
-	ProductID int
-	UserID    int
-	Type      string `gorm:"type:enum('in','out')"`
-	Quantity  int
-	Note      string
-	CreatedAt time.Time
-
-	Product Product `gorm:"foreignKey:ProductID"`
-	User    User    `gorm:"foreignKey:UserID"`
+	ID          int `gorm:"primaryKey"`
+	ProductID   int
+	UserID      int
+	WarehouseID int
+	Type        string `gorm:"type:enum('in','out','transfer','adjust')"`
+	Quantity    int
+	Note        string
+	CreatedAt   time.Time
+
+	// Reference menunjuk dokumen sumber movement, misalnya "stocktake:12".
+	// Quantity untuk type adjust bertanda (negatif berarti stok berkurang).
+	Reference string `gorm:"type:varchar(100);not null;default:'';index"`
+
+	// UnitCost dan TotalCost adalah nilai movement: harga beli untuk movement masuk, harga
+	// pokok (COGS) untuk movement keluar. TotalCost selalu positif, arahnya mengikuti
+	// Type (dan tanda Quantity untuk adjust). Transfer tidak bernilai.
+	UnitCost  float64 `gorm:"type:decimal(15,4);not null;default:0"`
+	TotalCost float64 `gorm:"type:decimal(15,2);not null;default:0"`
+
+	// UnitID dan UnitQuantity mencatat satuan dan jumlah yang diinput user. Quantity adalah
+	// hasil konversinya ke satuan dasar produk. UnitID kosong berarti diinput dalam satuan dasar.
+	UnitID       *int
+	UnitQuantity int `gorm:"not null;default:0"`
+
+	// DocumentID diisi jika movement merupakan baris dari StockDocument
+	DocumentID *int `gorm:"index"`
+
+	// ReservationID diisi jika movement keluar memakai stok dari sebuah reservasi
+	ReservationID *int `gorm:"index"`
+
+	// ToWarehouseID hanya diisi untuk movement bertipe transfer (gudang tujuan)
+	ToWarehouseID *int
+
+	// ReversalOfID diisi pada movement pembalik dan menunjuk ke movement asli,
+	// sedangkan ReversalID diisi pada movement asli setelah dibalik.
+	ReversalOfID *int
+	ReversalID   *int
+
+	// Lots berisi alokasi lot dari movement ini (kosong untuk stok tanpa lot)
+	Lots []StockMovementLot `gorm:"foreignKey:StockMovementID"`
+
+	// Serials berisi unit yang ikut dalam movement ini (hanya untuk produk serialized)
+	Serials []StockMovementSerial `gorm:"foreignKey:StockMovementID"`
+
+	Product     Product    `gorm:"foreignKey:ProductID"`
+	User        User       `gorm:"foreignKey:UserID"`
+	Warehouse   Warehouse  `gorm:"foreignKey:WarehouseID"`
+	ToWarehouse *Warehouse `gorm:"foreignKey:ToWarehouseID"`
+	Unit        *Unit      `gorm:"foreignKey:UnitID"`
 }
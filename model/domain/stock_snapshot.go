@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// StockSnapshot menyimpan saldo movement satu produk di satu gudang pada akhir hari
+// SnapshotDate. Saldo historis dihitung dari snapshot terakhir ditambah movement setelahnya,
+// sehingga tidak perlu menjumlahkan seluruh riwayat movement.
+type StockSnapshot struct {
+	ID           int       `gorm:"primaryKey"`
+	SnapshotDate time.Time `gorm:"type:date;not null;uniqueIndex:idx_stock_snapshot"`
+	ProductID    int       `gorm:"not null;uniqueIndex:idx_stock_snapshot"`
+	WarehouseID  int       `gorm:"not null;uniqueIndex:idx_stock_snapshot"`
+	Quantity     int       `gorm:"not null"`
+	CreatedAt    time.Time
+}
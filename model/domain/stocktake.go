@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Stocktake adalah sesi stock opname (perhitungan fisik) di satu gudang.
+type Stocktake struct {
+	ID          int `gorm:"primaryKey"`
+	WarehouseID int
+	CategoryID  *int
+	Status      string `gorm:"type:enum('open','approved','cancelled');default:'open'"`
+	Note        string
+	CreatedBy   int
+	ApprovedBy  *int
+	CreatedAt   time.Time
+	ApprovedAt  *time.Time
+
+	Lines     []StocktakeLine `gorm:"foreignKey:StocktakeID"`
+	Warehouse Warehouse       `gorm:"foreignKey:WarehouseID"`
+}
+
+// StocktakeLine menyimpan hasil hitung satu produk. SystemQuantity adalah saldo sistem saat
+// sesi dibuka, lalu diperbarui dengan saldo saat produk dihitung (dasar perhitungan selisih).
+type StocktakeLine struct {
+	ID              int `gorm:"primaryKey"`
+	StocktakeID     int `gorm:"index"`
+	ProductID       int
+	SystemQuantity  int
+	CountedQuantity *int
+	CountedBy       *int
+	CountedAt       *time.Time
+	MovementID      *int
+
+	Product Product `gorm:"foreignKey:ProductID"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type Supplier struct {
+	ID            int    `gorm:"primaryKey"`
+	Name          string `gorm:"type:varchar(150);unique"`
+	ContactPerson string `gorm:"type:varchar(100)"`
+	Email         string `gorm:"type:varchar(100)"`
+	Phone         string `gorm:"type:varchar(30)"`
+	Address       string `gorm:"type:varchar(255)"`
+	LeadTimeDays  int    `gorm:"not null;default:0"`
+	PaymentTerms  string `gorm:"type:varchar(100)"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ProductSupplier menghubungkan produk dengan supplier yang bisa memasoknya (many-to-many).
+// Satu produk hanya boleh punya satu supplier preferred.
+type ProductSupplier struct {
+	ID                int     `gorm:"primaryKey"`
+	ProductID         int     `gorm:"uniqueIndex:idx_product_supplier"`
+	SupplierID        int     `gorm:"uniqueIndex:idx_product_supplier;index"`
+	SupplierSKU       string  `gorm:"type:varchar(100)"`
+	LastPurchasePrice float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	Preferred         bool    `gorm:"not null;default:false"`
+	UpdatedAt         time.Time
+
+	Product  Product  `gorm:"foreignKey:ProductID"`
+	Supplier Supplier `gorm:"foreignKey:SupplierID"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Unit adalah satuan dalam katalog satuan (misalnya "pcs", "box", "kg")
+type Unit struct {
+	ID        int    `gorm:"primaryKey"`
+	Code      string `gorm:"type:varchar(20);not null;unique"`
+	Name      string `gorm:"type:varchar(100);not null"`
+	CreatedAt time.Time
+}
+
+// ProductUnit adalah satuan alternatif sebuah produk. Factor adalah jumlah satuan dasar
+// produk dalam satu satuan ini, misalnya 24 untuk box isi 24 pcs.
+type ProductUnit struct {
+	ID        int `gorm:"primaryKey"`
+	ProductID int `gorm:"not null;uniqueIndex:idx_product_unit"`
+	UnitID    int `gorm:"not null;uniqueIndex:idx_product_unit"`
+	Factor    int `gorm:"not null"`
+
+	Unit Unit `gorm:"foreignKey:UnitID"`
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	ID        int    `gorm:"primaryKey"`
@@ -9,4 +13,7 @@ type User struct {
 	Password  string `gorm:"type:text"`
 	Role      string `gorm:"type:enum('admin','staff')"`
 	CreatedAt time.Time
+
+	// DeletedAt diisi saat user dihapus (soft delete). User di trash tidak bisa login.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+type Warehouse struct {
+	ID        int    `gorm:"primaryKey"`
+	Name      string `gorm:"type:varchar(100);unique"`
+	Address   string `gorm:"type:varchar(255)"`
+	CreatedAt time.Time
+}
@@ -2,4 +2,14 @@ package web
 
 type CategoryCreateOrUpdateRequest struct {
 	Name string `json:"name" validate:"required"`
+	// Opsional: ID kategori induk, kosongkan untuk kategori teratas
+	ParentID *int `json:"parent_id" validate:"omitempty,gt=0"`
+}
+
+// CategoryDeleteRequest menentukan cara menghapus kategori yang masih berisi produk
+type CategoryDeleteRequest struct {
+	// refuse (default): tolak jika masih ada produk atau subkategori; reassign: pindahkan
+	// produk dan subkategori ke TargetID; cascade: hapus produk (soft delete)
+	Mode     string `validate:"omitempty,oneof=refuse reassign cascade"`
+	TargetID int    `validate:"required_if=Mode reassign,gte=0"`
 }
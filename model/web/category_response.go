@@ -1,6 +1,38 @@
 package web
 
+import "time"
+
 type CategoryResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id"`
+
+	// Path adalah breadcrumb dari kategori teratas sampai kategori ini, FullName
+	// gabungan namanya, misalnya "Elektronik > Audio > Headphone"
+	Path     []CategoryPathResponse `json:"path"`
+	FullName string                 `json:"full_name"`
+
+	// Diisi pada kategori di trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type CategoryPathResponse struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
 }
+
+type CategoryTreeResponse struct {
+	ID       int                    `json:"id"`
+	Name     string                 `json:"name"`
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+// CategoryDeleteResponse berisi jumlah data yang terdampak penghapusan kategori. Juga
+// dikirim bersama error 409 saat mode refuse menolak penghapusan.
+type CategoryDeleteResponse struct {
+	CategoryID       int    `json:"category_id"`
+	Mode             string `json:"mode"`
+	TargetCategoryID *int   `json:"target_category_id,omitempty"`
+	ProductCount     int    `json:"product_count"`
+	SubcategoryCount int    `json:"subcategory_count"`
+}
@@ -0,0 +1,20 @@
+package web
+
+// KitComponentsRequest mengganti bill of materials kit. Daftar kosong berarti produk tidak
+// lagi menjadi kit.
+type KitComponentsRequest struct {
+	Components []KitComponentRequest `json:"components" validate:"dive"`
+}
+
+type KitComponentRequest struct {
+	ProductID int `json:"product_id" validate:"required"`
+	// Jumlah komponen (dalam satuan dasar) untuk satu unit kit
+	Quantity int `json:"quantity" validate:"required,gte=1"`
+}
+
+// KitAssembleRequest merakit Quantity kit dari stok komponen di gudang WarehouseID
+type KitAssembleRequest struct {
+	WarehouseID int    `json:"warehouse_id" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,gte=1"`
+	Note        string `json:"note"`
+}
@@ -0,0 +1,41 @@
+package web
+
+import "time"
+
+// KitResponse berisi bill of materials kit dan ketersediaannya, untuk seluruh gudang atau
+// satu gudang jika WarehouseID diisi. Assembled adalah stok kit yang sudah dirakit,
+// Buildable jumlah kit yang masih bisa dirakit dari stok komponen yang tersedia, dan
+// Available = Assembled - Reserved + Buildable.
+type KitResponse struct {
+	ProductID   int                    `json:"product_id"`
+	Name        string                 `json:"name"`
+	WarehouseID *int                   `json:"warehouse_id,omitempty"`
+	Components  []KitComponentResponse `json:"components"`
+	Assembled   int                    `json:"assembled"`
+	Reserved    int                    `json:"reserved"`
+	Buildable   int                    `json:"buildable"`
+	Available   int                    `json:"available"`
+}
+
+// KitComponentResponse adalah satu komponen kit. Available adalah stok komponen yang tidak
+// ditahan reservasi, Buildable jumlah kit yang bisa dirakit dari komponen ini saja.
+type KitComponentResponse struct {
+	ProductID int    `json:"product_id"`
+	SKU       string `json:"sku"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	Available int    `json:"available"`
+	Buildable int    `json:"buildable"`
+}
+
+type KitAssemblyResponse struct {
+	ID          int                     `json:"id"`
+	KitID       int                     `json:"kit_id"`
+	WarehouseID int                     `json:"warehouse_id"`
+	Quantity    int                     `json:"quantity"`
+	Automatic   bool                    `json:"automatic"`
+	UserID      int                     `json:"user_id"`
+	Note        string                  `json:"note"`
+	CreatedAt   time.Time               `json:"created_at"`
+	Movements   []StockMovementResponse `json:"movements"`
+}
@@ -1,7 +1,36 @@
 package web
 
+// ProductCreateOrUpdateRequest tidak memuat stok. Stok produk adalah jumlah saldo per gudang
+// dan hanya berubah lewat stock movement, termasuk stok awal (movement in atau adjust).
 type ProductCreateOrUpdateRequest struct {
 	Name       string `json:"name" validate:"required"`
 	CategoryID int    `json:"category_id" validate:"required"`
-	Stock      int    `json:"stock" validate:"gte=0"`
+	Serialized bool   `json:"serialized"`
+	SKU        string `json:"sku" validate:"max=64"`
+
+	MinStock        int `json:"min_stock" validate:"gte=0"`
+	ReorderPoint    int `json:"reorder_point" validate:"gte=0"`
+	ReorderQuantity int `json:"reorder_quantity" validate:"gte=0"`
+}
+
+// ProductVariantCreateRequest membuat varian dari produk induk. Kategori, serialized, dan
+// satuan dasar mengikuti produk induk. Varian dibuat dengan stok 0 dan diisi lewat stock movement.
+type ProductVariantCreateRequest struct {
+	SKU string `json:"sku" validate:"required,max=64"`
+	// Opsional, default: nama induk diikuti nilai atribut, misalnya "Kaos (M, Red)"
+	Name string `json:"name" validate:"max=100"`
+
+	// Kombinasi atribut varian, misalnya {"size": "M", "color": "Red"}
+	Attributes map[string]string `json:"attributes" validate:"required,min=1,dive,keys,required,max=50,endkeys,required,max=100"`
+
+	MinStock        int `json:"min_stock" validate:"gte=0"`
+	ReorderPoint    int `json:"reorder_point" validate:"gte=0"`
+	ReorderQuantity int `json:"reorder_quantity" validate:"gte=0"`
+}
+
+type ProductBarcodeCreateRequest struct {
+	Code string `json:"code" validate:"required,max=64"`
+	// Opsional: ean13, upca, atau code128. Jika kosong ditentukan dari kode (13 digit EAN-13,
+	// 12 digit UPC-A, selain itu Code128).
+	Symbology string `json:"symbology" validate:"omitempty,oneof=ean13 upca code128"`
 }
@@ -1,8 +1,103 @@
 package web
 
+import "time"
+
 type ProductResponse struct {
 	ID         int    `json:"id"`
 	Name       string `json:"name"`
 	Stock      int    `json:"stock"`
 	CategoryID int    `json:"category_id"`
+	Serialized bool   `json:"serialized"`
+	SKU        string `json:"sku"`
+
+	// ParentID dan Attributes diisi pada varian. VariantCount diisi pada produk induk,
+	// dan stoknya adalah jumlah stok seluruh variannya.
+	ParentID     *int              `json:"parent_id,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	VariantCount int               `json:"variant_count,omitempty"`
+
+	Barcodes []ProductBarcodeResponse `json:"barcodes,omitempty"`
+
+	// Gambar (beserta thumbnail) dan dokumen produk seperti datasheet atau MSDS
+	Images    []ProductAttachmentResponse `json:"images,omitempty"`
+	Documents []ProductAttachmentResponse `json:"documents,omitempty"`
+
+	// Components diisi jika produk sebuah kit. Buildable adalah jumlah kit yang masih bisa
+	// dirakit dari stok komponen dan ikut dihitung dalam Available.
+	Components []KitComponentResponse `json:"components,omitempty"`
+	Buildable  int                    `json:"buildable,omitempty"`
+
+	MinStock        int  `json:"min_stock"`
+	ReorderPoint    int  `json:"reorder_point"`
+	ReorderQuantity int  `json:"reorder_quantity"`
+	LowStock        bool `json:"low_stock"`
+
+	// OnHand sama dengan Stock (stok fisik), Reserved ditahan reservasi aktif,
+	// Available = OnHand - Reserved
+	OnHand    int `json:"on_hand"`
+	Reserved  int `json:"reserved"`
+	Available int `json:"available"`
+
+	Warehouses []ProductWarehouseStockResponse `json:"warehouses,omitempty"`
+
+	// Satuan dasar (satuan Stock) dan satuan alternatif produk
+	BaseUnit string                `json:"base_unit,omitempty"`
+	Units    []ProductUnitResponse `json:"units,omitempty"`
+
+	// Diisi jika diminta parameter unit: stok dalam satuan tersebut
+	Display *ProductDisplayStockResponse `json:"display,omitempty"`
+
+	// Diisi pada produk di trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type ProductDisplayStockResponse struct {
+	Unit      string  `json:"unit"`
+	OnHand    float64 `json:"on_hand"`
+	Reserved  float64 `json:"reserved"`
+	Available float64 `json:"available"`
+}
+
+type ProductWarehouseStockResponse struct {
+	WarehouseID int    `json:"warehouse_id"`
+	Warehouse   string `json:"warehouse"`
+	Stock       int    `json:"stock"`
+	Reserved    int    `json:"reserved"`
+	Available   int    `json:"available"`
+}
+
+// LowStockProductResponse adalah produk yang stoknya di bawah ambang batas. Shortage adalah
+// selisih stok terhadap ambang batas, AlertID diisi jika alert-nya masih open.
+type LowStockProductResponse struct {
+	ProductID       int        `json:"product_id"`
+	Name            string     `json:"name"`
+	Stock           int        `json:"stock"`
+	MinStock        int        `json:"min_stock"`
+	ReorderPoint    int        `json:"reorder_point"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	Threshold       int        `json:"threshold"`
+	Shortage        int        `json:"shortage"`
+	AlertID         *int       `json:"alert_id,omitempty"`
+	AlertRaisedAt   *time.Time `json:"alert_raised_at,omitempty"`
+}
+
+// ProductAttachmentResponse berisi URL unduhan berkas dan thumbnail-nya. Keduanya endpoint API
+// yang memerlukan header Authorization.
+type ProductAttachmentResponse struct {
+	ID           int       `json:"id"`
+	Kind         string    `json:"kind"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	Width        int       `json:"width,omitempty"`
+	Height       int       `json:"height,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ProductBarcodeResponse struct {
+	ID        int    `json:"id"`
+	Code      string `json:"code"`
+	Symbology string `json:"symbology"`
 }
@@ -0,0 +1,38 @@
+package web
+
+type PurchaseOrderCreateOrUpdateRequest struct {
+	Supplier     string                     `json:"supplier" validate:"required,max=150"`
+	WarehouseID  int                        `json:"warehouse_id" validate:"required"`
+	ExpectedDate string                     `json:"expected_date" validate:"omitempty,datetime=2006-01-02"`
+	Note         string                     `json:"note"`
+	Lines        []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type PurchaseOrderLineRequest struct {
+	ProductID int     `json:"product_id" validate:"required"`
+	Quantity  int     `json:"quantity" validate:"required,gt=0"`
+	UnitCost  float64 `json:"unit_cost" validate:"gte=0"`
+	Note      string  `json:"note"`
+}
+
+type PurchaseOrderReceiveRequest struct {
+	// Gudang penerima, kosongkan untuk memakai gudang pada PO
+	WarehouseID int    `json:"warehouse_id"`
+	Note        string `json:"note"`
+
+	// Hanya berlaku untuk admin: izinkan penerimaan melebihi sisa quantity yang dipesan
+	AllowOverReceipt bool `json:"allow_over_receipt"`
+
+	Lines []PurchaseOrderReceiveLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type PurchaseOrderReceiveLineRequest struct {
+	LineID        int      `json:"line_id" validate:"required"`
+	Quantity      int      `json:"quantity" validate:"required,gt=0"`
+	LotNumber     string   `json:"lot_number" validate:"required_with=ExpiryDate"`
+	ExpiryDate    string   `json:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+	SerialNumbers []string `json:"serial_numbers" validate:"dive,required"`
+
+	// Harga beli per unit sesuai faktur, kosongkan untuk memakai harga pada baris PO
+	UnitCost *float64 `json:"unit_cost" validate:"omitempty,gte=0"`
+}
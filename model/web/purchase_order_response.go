@@ -0,0 +1,30 @@
+package web
+
+import "time"
+
+type PurchaseOrderResponse struct {
+	ID           int                         `json:"id"`
+	Supplier     string                      `json:"supplier"`
+	WarehouseID  int                         `json:"warehouse_id"`
+	Status       string                      `json:"status"`
+	ExpectedDate string                      `json:"expected_date,omitempty"`
+	Note         string                      `json:"note"`
+	CreatedBy    int                         `json:"created_by"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+	Lines        []PurchaseOrderLineResponse `json:"lines"`
+
+	// Receipts berisi movement "in" yang diposting saat penerimaan (hanya pada detail PO)
+	Receipts []StockMovementResponse `json:"receipts,omitempty"`
+}
+
+type PurchaseOrderLineResponse struct {
+	ID                int     `json:"id"`
+	ProductID         int     `json:"product_id"`
+	Product           string  `json:"product"`
+	OrderedQuantity   int     `json:"ordered_quantity"`
+	ReceivedQuantity  int     `json:"received_quantity"`
+	RemainingQuantity int     `json:"remaining_quantity"`
+	UnitCost          float64 `json:"unit_cost"`
+	Note              string  `json:"note"`
+}
@@ -0,0 +1,34 @@
+package web
+
+import "time"
+
+// ReconciliationResponse adalah hasil pencocokan stok tersimpan dengan saldo dari stock movement.
+// Hanya produk yang memiliki selisih yang dicantumkan pada Drifts.
+type ReconciliationResponse struct {
+	CheckedAt       time.Time              `json:"checked_at"`
+	ProductsChecked int                    `json:"products_checked"`
+	Repaired        bool                   `json:"repaired"`
+	Reference       string                 `json:"reference,omitempty"`
+	Drifts          []ProductDriftResponse `json:"drifts"`
+}
+
+// ProductDriftResponse membandingkan Product.Stock (StoredStock), jumlah saldo per gudang
+// (WarehouseTotal), dan saldo dari movement (ExpectedStock)
+type ProductDriftResponse struct {
+	ProductID      int                      `json:"product_id"`
+	Name           string                   `json:"name"`
+	StoredStock    int                      `json:"stored_stock"`
+	WarehouseTotal int                      `json:"warehouse_total"`
+	ExpectedStock  int                      `json:"expected_stock"`
+	Difference     int                      `json:"difference"`
+	Warehouses     []WarehouseDriftResponse `json:"warehouses"`
+}
+
+type WarehouseDriftResponse struct {
+	WarehouseID          int    `json:"warehouse_id"`
+	Warehouse            string `json:"warehouse"`
+	StoredStock          int    `json:"stored_stock"`
+	ExpectedStock        int    `json:"expected_stock"`
+	Difference           int    `json:"difference"`
+	AdjustmentMovementID *int   `json:"adjustment_movement_id,omitempty"`
+}
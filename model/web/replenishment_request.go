@@ -0,0 +1,11 @@
+package web
+
+// ReplenishmentQuery adalah parameter perhitungan saran pembelian. LeadTimeDays dipakai
+// untuk produk yang tidak punya supplier preferred (atau lead time supplier-nya 0).
+type ReplenishmentQuery struct {
+	WindowDays   int `validate:"gte=1,lte=365"`
+	LeadTimeDays int `validate:"gte=0,lte=365"`
+	SafetyDays   int `validate:"gte=0,lte=365"`
+	SupplierID   int `validate:"gte=0"`
+	OnlyNeeded   bool
+}
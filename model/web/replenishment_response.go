@@ -0,0 +1,25 @@
+package web
+
+// ReplenishmentSuggestionResponse adalah saran pembelian satu produk. AvgDailyConsumption
+// dihitung dari movement keluar selama WindowDays terakhir. DaysOfCover dan StockoutDate
+// kosong jika produk tidak punya pemakaian pada periode tersebut.
+type ReplenishmentSuggestionResponse struct {
+	ProductID           int      `json:"product_id"`
+	Name                string   `json:"name"`
+	OnHand              int      `json:"on_hand"`
+	Reserved            int      `json:"reserved"`
+	Available           int      `json:"available"`
+	OnOrder             int      `json:"on_order"`
+	Consumed            int      `json:"consumed"`
+	WindowDays          int      `json:"window_days"`
+	AvgDailyConsumption float64  `json:"avg_daily_consumption"`
+	DaysOfCover         *float64 `json:"days_of_cover"`
+	StockoutDate        string   `json:"stockout_date,omitempty"`
+	LeadTimeDays        int      `json:"lead_time_days"`
+	SafetyStock         int      `json:"safety_stock"`
+	ReorderLevel        int      `json:"reorder_level"`
+	SuggestedQuantity   int      `json:"suggested_quantity"`
+	SupplierID          *int     `json:"supplier_id"`
+	Supplier            string   `json:"supplier,omitempty"`
+	SupplierSKU         string   `json:"supplier_sku,omitempty"`
+}
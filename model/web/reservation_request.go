@@ -0,0 +1,12 @@
+package web
+
+type ReservationCreateRequest struct {
+	ProductID   int    `json:"product_id" validate:"required"`
+	WarehouseID int    `json:"warehouse_id" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+	Owner       string `json:"owner" validate:"required,max=100"`
+	Note        string `json:"note"`
+
+	// Batas waktu reservasi (RFC3339, contoh 2025-07-01T17:00:00+07:00). Kosongkan jika tanpa batas.
+	ExpiresAt string `json:"expires_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,17 @@
+package web
+
+import "time"
+
+type ReservationResponse struct {
+	ID               int        `json:"id"`
+	ProductID        int        `json:"product_id"`
+	WarehouseID      int        `json:"warehouse_id"`
+	Quantity         int        `json:"quantity"`
+	ReservedQuantity int        `json:"reserved_quantity"`
+	Owner            string     `json:"owner"`
+	Note             string     `json:"note"`
+	Status           string     `json:"status"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	CreatedBy        int        `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
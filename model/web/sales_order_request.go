@@ -0,0 +1,27 @@
+package web
+
+type SalesOrderCreateOrUpdateRequest struct {
+	// Customer atau departemen yang meminta barang
+	Customer    string                  `json:"customer" validate:"required,max=150"`
+	WarehouseID int                     `json:"warehouse_id" validate:"required"`
+	Note        string                  `json:"note"`
+	Lines       []SalesOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type SalesOrderLineRequest struct {
+	ProductID int    `json:"product_id" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+	Note      string `json:"note"`
+}
+
+type SalesOrderShipRequest struct {
+	Note  string                      `json:"note"`
+	Lines []SalesOrderShipLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type SalesOrderShipLineRequest struct {
+	LineID        int      `json:"line_id" validate:"required"`
+	Quantity      int      `json:"quantity" validate:"required,gt=0"`
+	LotNumber     string   `json:"lot_number"`
+	SerialNumbers []string `json:"serial_numbers" validate:"dive,required"`
+}
@@ -0,0 +1,33 @@
+package web
+
+import "time"
+
+type SalesOrderResponse struct {
+	ID          int                      `json:"id"`
+	Customer    string                   `json:"customer"`
+	WarehouseID int                      `json:"warehouse_id"`
+	Status      string                   `json:"status"`
+	Note        string                   `json:"note"`
+	Backordered bool                     `json:"backordered"`
+	CreatedBy   int                      `json:"created_by"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Lines       []SalesOrderLineResponse `json:"lines"`
+
+	// Shipments berisi movement "out" yang diposting saat pengiriman (hanya pada detail order)
+	Shipments []StockMovementResponse `json:"shipments,omitempty"`
+}
+
+// SalesOrderLineResponse: ReservedQuantity adalah stok yang masih ditahan untuk baris ini,
+// BackorderQuantity sisa pesanan yang belum terkirim dan belum tertahan stoknya.
+type SalesOrderLineResponse struct {
+	ID                int    `json:"id"`
+	ProductID         int    `json:"product_id"`
+	Product           string `json:"product"`
+	OrderedQuantity   int    `json:"ordered_quantity"`
+	ShippedQuantity   int    `json:"shipped_quantity"`
+	ReservedQuantity  int    `json:"reserved_quantity"`
+	BackorderQuantity int    `json:"backorder_quantity"`
+	ReservationID     *int   `json:"reservation_id"`
+	Note              string `json:"note"`
+}
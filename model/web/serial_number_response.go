@@ -0,0 +1,9 @@
+package web
+
+type SerialNumberResponse struct {
+	Serial      string                  `json:"serial"`
+	ProductID   int                     `json:"product_id"`
+	Status      string                  `json:"status"`
+	WarehouseID int                     `json:"warehouse_id"`
+	History     []StockMovementResponse `json:"history"`
+}
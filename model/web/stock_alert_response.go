@@ -0,0 +1,16 @@
+package web
+
+import "time"
+
+type StockAlertResponse struct {
+	ID                 int        `json:"id"`
+	ProductID          int        `json:"product_id"`
+	Product            string     `json:"product"`
+	Status             string     `json:"status"`
+	Stock              int        `json:"stock"`
+	Threshold          int        `json:"threshold"`
+	MovementID         *int       `json:"movement_id"`
+	ResolvedMovementID *int       `json:"resolved_movement_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	ResolvedAt         *time.Time `json:"resolved_at"`
+}
@@ -0,0 +1,23 @@
+package web
+
+// StockBalanceResponse adalah saldo stok produk pada akhir hari AsOf
+type StockBalanceResponse struct {
+	ProductID  int                        `json:"product_id"`
+	Name       string                     `json:"name"`
+	CategoryID int                        `json:"category_id"`
+	AsOf       string                     `json:"as_of"`
+	Quantity   int                        `json:"quantity"`
+	Warehouses []WarehouseBalanceResponse `json:"warehouses"`
+
+	// Diisi jika diminta parameter unit: Quantity dalam satuan tersebut
+	DisplayUnit     string   `json:"display_unit,omitempty"`
+	DisplayQuantity *float64 `json:"display_quantity,omitempty"`
+}
+
+type WarehouseBalanceResponse struct {
+	WarehouseID int    `json:"warehouse_id"`
+	Warehouse   string `json:"warehouse"`
+	Quantity    int    `json:"quantity"`
+
+	DisplayQuantity *float64 `json:"display_quantity,omitempty"`
+}
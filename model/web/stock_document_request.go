@@ -0,0 +1,22 @@
+package web
+
+type StockDocumentCreateRequest struct {
+	Type            string                     `json:"type" validate:"required,oneof=in out transfer"`
+	ReferenceNumber string                     `json:"reference_number" validate:"max=100"`
+	WarehouseID     int                        `json:"warehouse_id" validate:"required"`
+	ToWarehouseID   int                        `json:"to_warehouse_id" validate:"required_if=Type transfer"`
+	Note            string                     `json:"note"`
+	Lines           []StockDocumentLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type StockDocumentLineRequest struct {
+	ProductID     int      `json:"product_id" validate:"required"`
+	Quantity      int      `json:"quantity" validate:"required,gt=0"`
+	Note          string   `json:"note"`
+	LotNumber     string   `json:"lot_number" validate:"required_with=ExpiryDate"`
+	ExpiryDate    string   `json:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+	SerialNumbers []string `json:"serial_numbers" validate:"dive,required"`
+
+	// Opsional untuk dokumen "in": harga beli per unit
+	UnitCost *float64 `json:"unit_cost" validate:"omitempty,gte=0"`
+}
@@ -0,0 +1,22 @@
+package web
+
+import "time"
+
+type StockDocumentResponse struct {
+	ID              int                     `json:"id"`
+	Type            string                  `json:"type"`
+	ReferenceNumber string                  `json:"reference_number"`
+	WarehouseID     int                     `json:"warehouse_id"`
+	ToWarehouseID   *int                    `json:"to_warehouse_id"`
+	Note            string                  `json:"note"`
+	UserID          int                     `json:"user_id"`
+	CreatedAt       time.Time               `json:"created_at"`
+	Movements       []StockMovementResponse `json:"movements"`
+}
+
+// StockDocumentLineError menjelaskan kenapa satu baris dokumen ditolak (line dimulai dari 1)
+type StockDocumentLineError struct {
+	Line      int    `json:"line"`
+	ProductID int    `json:"product_id"`
+	Error     string `json:"error"`
+}
@@ -0,0 +1,11 @@
+package web
+
+type StockLotResponse struct {
+	ID           int    `json:"id"`
+	ProductID    int    `json:"product_id"`
+	WarehouseID  int    `json:"warehouse_id"`
+	LotNumber    string `json:"lot_number"`
+	ExpiryDate   string `json:"expiry_date,omitempty"`
+	DaysToExpiry *int   `json:"days_to_expiry,omitempty"`
+	Stock        int    `json:"stock"`
+}
@@ -1,8 +1,33 @@
 package web
 
 type StockMovementCreateRequest struct {
-	ProductID int    `json:"product_id" validate:"required"`
-	Type      string `json:"type" validate:"required,oneof=in out"`
-	Quantity  int    `json:"quantity" validate:"required,gt=0"`
-	Note      string `json:"note"`
+	// Produk bisa ditunjuk dengan product_id atau product_code (SKU atau barcode hasil scan)
+	ProductID     int    `json:"product_id" validate:"required_without=ProductCode"`
+	ProductCode   string `json:"product_code" validate:"max=64"`
+	WarehouseID   int    `json:"warehouse_id" validate:"required"`
+	ToWarehouseID int    `json:"to_warehouse_id" validate:"required_if=Type transfer"`
+	Type          string `json:"type" validate:"required,oneof=in out transfer"`
+	Quantity      int    `json:"quantity" validate:"required,gt=0"`
+	Note          string `json:"note"`
+
+	// Opsional: kode satuan Quantity (misalnya "box"), kosongkan untuk satuan dasar produk
+	Unit string `json:"unit"`
+
+	// Untuk type "in": nomor lot dan tanggal kedaluwarsa (YYYY-MM-DD) barang yang diterima.
+	// Untuk "out"/"transfer": nomor lot yang diambil, kosongkan untuk alokasi FEFO otomatis.
+	LotNumber  string `json:"lot_number" validate:"required_with=ExpiryDate"`
+	ExpiryDate string `json:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+
+	// Wajib untuk produk serialized: daftar serial number unit yang masuk/keluar/dipindah
+	SerialNumbers []string `json:"serial_numbers" validate:"dive,required"`
+
+	// Opsional untuk type "in": harga beli per unit, kosongkan untuk memakai average cost produk
+	UnitCost *float64 `json:"unit_cost" validate:"omitempty,gte=0"`
+
+	// Opsional untuk type "out": ID reservasi yang stoknya dipakai
+	ReservationID int `json:"reservation_id" validate:"gte=0"`
+}
+
+type StockMovementReverseRequest struct {
+	Reason string `json:"reason" validate:"required"`
 }
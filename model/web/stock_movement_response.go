@@ -3,13 +3,40 @@ package web
 import "time"
 
 type StockMovementResponse struct {
-	ID        int       `json:"id"`
-	ProductID int       `json:"product_id"`
-	Product   string    `json:"product"`
-	UserID    int       `json:"user_id"`
-	User      string    `json:"user"`
-	Type      string    `json:"type"`
-	Quantity  int       `json:"quantity"`
-	Note      string    `json:"note"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int       `json:"id"`
+	ProductID     int       `json:"product_id"`
+	Product       string    `json:"product"`
+	UserID        int       `json:"user_id"`
+	User          string    `json:"user"`
+	WarehouseID   int       `json:"warehouse_id"`
+	ToWarehouseID *int      `json:"to_warehouse_id"`
+	Type          string    `json:"type"`
+	Quantity      int       `json:"quantity"`
+	Unit          string    `json:"unit,omitempty"`
+	UnitQuantity  int       `json:"unit_quantity,omitempty"`
+	Note          string    `json:"note"`
+	Reference     string    `json:"reference"`
+	DocumentID    *int      `json:"document_id"`
+	ReservationID *int      `json:"reservation_id"`
+	UnitCost      float64   `json:"unit_cost"`
+	TotalCost     float64   `json:"total_cost"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Diisi jika diminta parameter unit: Quantity dalam satuan tersebut
+	DisplayUnit     string   `json:"display_unit,omitempty"`
+	DisplayQuantity *float64 `json:"display_quantity,omitempty"`
+
+	Reversed     bool `json:"reversed"`
+	ReversalID   *int `json:"reversal_id"`
+	ReversalOfID *int `json:"reversal_of_id"`
+
+	Lots          []StockMovementLotResponse `json:"lots,omitempty"`
+	SerialNumbers []string                   `json:"serial_numbers,omitempty"`
+}
+
+type StockMovementLotResponse struct {
+	LotID      int    `json:"lot_id"`
+	LotNumber  string `json:"lot_number"`
+	ExpiryDate string `json:"expiry_date,omitempty"`
+	Quantity   int    `json:"quantity"`
 }
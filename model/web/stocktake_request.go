@@ -0,0 +1,17 @@
+package web
+
+type StocktakeCreateRequest struct {
+	WarehouseID int    `json:"warehouse_id" validate:"required"`
+	CategoryID  int    `json:"category_id" validate:"required_without=ProductIDs"`
+	ProductIDs  []int  `json:"product_ids" validate:"required_without=CategoryID,dive,required"`
+	Note        string `json:"note"`
+}
+
+type StocktakeCountRequest struct {
+	Counts []StocktakeCountLineRequest `json:"counts" validate:"required,min=1,dive"`
+}
+
+type StocktakeCountLineRequest struct {
+	ProductID       int  `json:"product_id" validate:"required"`
+	CountedQuantity *int `json:"counted_quantity" validate:"required,gte=0"`
+}
@@ -0,0 +1,25 @@
+package web
+
+import "time"
+
+type StocktakeResponse struct {
+	ID          int                     `json:"id"`
+	WarehouseID int                     `json:"warehouse_id"`
+	CategoryID  *int                    `json:"category_id"`
+	Status      string                  `json:"status"`
+	Note        string                  `json:"note"`
+	CreatedBy   int                     `json:"created_by"`
+	ApprovedBy  *int                    `json:"approved_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+	ApprovedAt  *time.Time              `json:"approved_at"`
+	Lines       []StocktakeLineResponse `json:"lines"`
+}
+
+type StocktakeLineResponse struct {
+	ProductID       int    `json:"product_id"`
+	Product         string `json:"product"`
+	SystemQuantity  int    `json:"system_quantity"`
+	CountedQuantity *int   `json:"counted_quantity"`
+	Variance        *int   `json:"variance"`
+	MovementID      *int   `json:"movement_id"`
+}
@@ -0,0 +1,17 @@
+package web
+
+type SupplierCreateOrUpdateRequest struct {
+	Name          string `json:"name" validate:"required,max=150"`
+	ContactPerson string `json:"contact_person" validate:"max=100"`
+	Email         string `json:"email" validate:"omitempty,email,max=100"`
+	Phone         string `json:"phone" validate:"max=30"`
+	Address       string `json:"address" validate:"max=255"`
+	LeadTimeDays  int    `json:"lead_time_days" validate:"gte=0"`
+	PaymentTerms  string `json:"payment_terms" validate:"max=100"`
+}
+
+type ProductSupplierRequest struct {
+	SupplierSKU       string  `json:"supplier_sku" validate:"max=100"`
+	LastPurchasePrice float64 `json:"last_purchase_price" validate:"gte=0"`
+	Preferred         bool    `json:"preferred"`
+}
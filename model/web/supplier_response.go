@@ -0,0 +1,23 @@
+package web
+
+type SupplierResponse struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	ContactPerson string `json:"contact_person"`
+	Email         string `json:"email"`
+	Phone         string `json:"phone"`
+	Address       string `json:"address"`
+	LeadTimeDays  int    `json:"lead_time_days"`
+	PaymentTerms  string `json:"payment_terms"`
+}
+
+type ProductSupplierResponse struct {
+	ProductID         int     `json:"product_id"`
+	Product           string  `json:"product,omitempty"`
+	SupplierID        int     `json:"supplier_id"`
+	Supplier          string  `json:"supplier,omitempty"`
+	SupplierSKU       string  `json:"supplier_sku"`
+	LastPurchasePrice float64 `json:"last_purchase_price"`
+	Preferred         bool    `json:"preferred"`
+	LeadTimeDays      int     `json:"lead_time_days"`
+}
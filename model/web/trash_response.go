@@ -0,0 +1,8 @@
+package web
+
+// PurgeResponse dikirim bersama error 409 saat purge ditolak. References berisi jumlah
+// rujukan per tabel, misalnya {"stock_movements": 12}.
+type PurgeResponse struct {
+	ID         int            `json:"id"`
+	References map[string]int `json:"references"`
+}
@@ -0,0 +1,19 @@
+package web
+
+type UnitCreateOrUpdateRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// ProductUnitsRequest mengatur satuan dasar produk dan satuan alternatifnya
+type ProductUnitsRequest struct {
+	BaseUnit string               `json:"base_unit" validate:"required"`
+	Units    []ProductUnitRequest `json:"units" validate:"dive"`
+}
+
+type ProductUnitRequest struct {
+	// Kode satuan dari katalog satuan
+	Unit string `json:"unit" validate:"required"`
+	// Jumlah satuan dasar dalam satu satuan ini, misalnya 24 untuk box isi 24 pcs
+	Factor int `json:"factor" validate:"required,gt=1"`
+}
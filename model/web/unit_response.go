@@ -0,0 +1,19 @@
+package web
+
+type UnitResponse struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type ProductUnitsResponse struct {
+	ProductID int                   `json:"product_id"`
+	BaseUnit  string                `json:"base_unit"`
+	Units     []ProductUnitResponse `json:"units"`
+}
+
+type ProductUnitResponse struct {
+	Unit   string `json:"unit"`
+	Name   string `json:"name"`
+	Factor int    `json:"factor"`
+}
@@ -1,8 +1,13 @@
 package web
 
+import "time"
+
 type UserResponse struct {
 	ID    int    `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
 	Role  string `json:"role"`
+
+	// Diisi pada user di trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
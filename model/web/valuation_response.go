@@ -0,0 +1,29 @@
+package web
+
+// StockValuationResponse adalah nilai persediaan pada akhir hari AsOf, dihitung dari
+// quantity dan nilai semua movement sampai tanggal tersebut.
+type StockValuationResponse struct {
+	AsOf          string                      `json:"as_of"`
+	CostingMethod string                      `json:"costing_method"`
+	TotalQuantity int                         `json:"total_quantity"`
+	TotalValue    float64                     `json:"total_value"`
+	Categories    []CategoryValuationResponse `json:"categories"`
+	Products      []ProductValuationResponse  `json:"products"`
+}
+
+type CategoryValuationResponse struct {
+	CategoryID int     `json:"category_id"`
+	Category   string  `json:"category"`
+	Quantity   int     `json:"quantity"`
+	TotalValue float64 `json:"total_value"`
+}
+
+type ProductValuationResponse struct {
+	ProductID  int     `json:"product_id"`
+	Name       string  `json:"name"`
+	CategoryID int     `json:"category_id"`
+	Category   string  `json:"category"`
+	Quantity   int     `json:"quantity"`
+	UnitCost   float64 `json:"unit_cost"`
+	TotalValue float64 `json:"total_value"`
+}
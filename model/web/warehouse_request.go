@@ -0,0 +1,6 @@
+package web
+
+type WarehouseCreateOrUpdateRequest struct {
+	Name    string `json:"name" validate:"required"`
+	Address string `json:"address"`
+}
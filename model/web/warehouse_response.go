@@ -0,0 +1,7 @@
+package web
+
+type WarehouseResponse struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
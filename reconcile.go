@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"inventory-management-api/service"
+	"log"
+	"os"
+)
+
+// runReconcile menjalankan rekonsiliasi stok dari command line dan mencetak hasilnya
+// sebagai JSON. Exit code 1 jika ada selisih yang tidak diperbaiki (berguna untuk cron),
+// 2 jika terjadi error.
+func runReconcile(s service.ReconciliationService, userRepo repository.UserRepository, args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	repair := flags.Bool("repair", false, "catat movement adjust untuk setiap selisih")
+	userID := flags.Int("user-id", 0, "user yang tercatat pada movement adjust (default: admin pertama)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var (
+		result web.ReconciliationResponse
+		err    error
+	)
+	if *repair {
+		if *userID == 0 {
+			*userID, err = firstAdminID(userRepo)
+			if err != nil {
+				log.Printf("❌ Gagal mencari user admin: %v", err)
+				return 2
+			}
+		}
+		result, err = s.Repair(*userID)
+	} else {
+		result, err = s.Check()
+	}
+	if err != nil {
+		log.Printf("❌ Rekonsiliasi gagal: %v", err)
+		return 2
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Printf("❌ Gagal menulis hasil: %v", err)
+		return 2
+	}
+
+	if !result.Repaired && len(result.Drifts) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func firstAdminID(userRepo repository.UserRepository) (int, error) {
+	users, err := userRepo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+	for _, u := range users {
+		if u.Role == "admin" {
+			return u.ID, nil
+		}
+	}
+	return 0, errors.New("no admin user found")
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"inventory-management-api/model/domain"
 
 	"gorm.io/gorm"
@@ -12,6 +13,16 @@ type CategoryRepository interface {
 	Save(category domain.Category) (domain.Category, error)
 	Update(category domain.Category) (domain.Category, error)
 	Delete(id int) error
+	FindSubtreeIds(id int) ([]int, error)
+	CountChildren(id int) (int, error)
+	CountProducts(id int) (int, error)
+	DeleteWithPolicy(deletion domain.CategoryDeletion) error
+
+	FindTrashed() ([]domain.Category, error)
+	FindTrashedById(id int) (domain.Category, error)
+	Restore(id int) error
+	CountReferences(id int) (map[string]int, error)
+	Purge(id int) error
 }
 
 type categoryRepository struct {
@@ -43,7 +54,8 @@ func (r *categoryRepository) Update(category domain.Category) (domain.Category,
 	err := r.db.Model(&domain.Category{}).
 		Where("id = ?", category.ID).
 		Updates(map[string]interface{}{
-			"name": category.Name,
+			"name":      category.Name,
+			"parent_id": category.ParentID,
 		}).Error
 
 	if err != nil {
@@ -65,3 +77,122 @@ func (r *categoryRepository) Delete(id int) error {
 	}
 	return nil
 }
+
+// FindSubtreeIds mengembalikan ID kategori id beserta seluruh turunannya
+func (r *categoryRepository) FindSubtreeIds(id int) ([]int, error) {
+	return categorySubtreeIds(r.db, id)
+}
+
+func (r *categoryRepository) CountChildren(id int) (int, error) {
+	var count int64
+	err := r.db.Model(&domain.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return int(count), err
+}
+
+// CountProducts menghitung produk yang langsung berada di kategori id (tanpa subkategori)
+func (r *categoryRepository) CountProducts(id int) (int, error) {
+	var count int64
+	err := r.db.Model(&domain.Product{}).Where("category_id = ?", id).Count(&count).Error
+	return int(count), err
+}
+
+// DeleteWithPolicy menghapus kategori sesuai deletion.Mode dalam satu transaksi: reassign
+// memindahkan produk dan subkategori ke kategori tujuan, cascade menghapus (soft delete)
+// produknya. Penghapusan dicatat sebagai CategoryDeletion.
+func (r *categoryRepository) DeleteWithPolicy(deletion domain.CategoryDeletion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		switch deletion.Mode {
+		case "reassign":
+			err := tx.Model(&domain.Product{}).Where("category_id = ?", deletion.CategoryID).
+				Update("category_id", *deletion.TargetCategoryID).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Model(&domain.Category{}).Where("parent_id = ?", deletion.CategoryID).
+				Update("parent_id", *deletion.TargetCategoryID).Error
+			if err != nil {
+				return err
+			}
+		case "cascade":
+			if err := tx.Where("category_id = ?", deletion.CategoryID).Delete(&domain.Product{}).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Delete(&domain.Category{}, deletion.CategoryID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(&deletion).Error
+	})
+}
+
+// FindTrashed mengambil kategori yang sudah dihapus (soft delete), terbaru lebih dulu
+func (r *categoryRepository) FindTrashed() ([]domain.Category, error) {
+	var categories []domain.Category
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&categories).Error
+	return categories, err
+}
+
+func (r *categoryRepository) FindTrashedById(id int) (domain.Category, error) {
+	var category domain.Category
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&category, id).Error
+	return category, err
+}
+
+func (r *categoryRepository) Restore(id int) error {
+	return r.db.Unscoped().Model(&domain.Category{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// CountReferences menghitung produk (termasuk yang di trash), subkategori, dan stocktake
+// yang masih merujuk kategori id
+func (r *categoryRepository) CountReferences(id int) (map[string]int, error) {
+	return countReferences(r.db, id, []reference{
+		{Name: "products", Model: &domain.Product{}, Where: "category_id = ?"},
+		{Name: "subcategories", Model: &domain.Category{}, Where: "parent_id = ?"},
+		{Name: "stocktakes", Model: &domain.Stocktake{}, Where: "category_id = ?"},
+	})
+}
+
+// Purge menghapus permanen kategori. Pastikan CountReferences kosong sebelum memanggilnya.
+func (r *categoryRepository) Purge(id int) error {
+	result := r.db.Unscoped().Delete(&domain.Category{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("category not found")
+	}
+	return nil
+}
+
+// categorySubtreeIds menelusuri pohon kategori di memori (tabel kategori kecil) mulai dari
+// id, termasuk id itu sendiri
+func categorySubtreeIds(db *gorm.DB, id int) ([]int, error) {
+	var categories []domain.Category
+	if err := db.Select("id", "parent_id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	children := map[int][]int{}
+	for _, c := range categories {
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c.ID)
+		}
+	}
+
+	ids := []int{id}
+	visited := map[int]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !visited[child] {
+				visited[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CostLayerRepository interface {
+	Save(layer domain.CostLayer, tx *gorm.DB) (domain.CostLayer, error)
+	FindOpenForUpdate(productID int, tx *gorm.DB) ([]domain.CostLayer, error)
+	UpdateRemaining(id int, remaining int, tx *gorm.DB) error
+}
+
+type costLayerRepository struct {
+	db *gorm.DB
+}
+
+func NewCostLayerRepository(db *gorm.DB) CostLayerRepository {
+	return &costLayerRepository{db: db}
+}
+
+func (r *costLayerRepository) Save(layer domain.CostLayer, tx *gorm.DB) (domain.CostLayer, error) {
+	err := tx.Create(&layer).Error
+	return layer, err
+}
+
+// FindOpenForUpdate mengambil layer yang masih bersisa, urut dari yang paling lama (FIFO)
+func (r *costLayerRepository) FindOpenForUpdate(productID int, tx *gorm.DB) ([]domain.CostLayer, error) {
+	var layers []domain.CostLayer
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND remaining > 0", productID).
+		Order("created_at asc, id asc").
+		Find(&layers).Error
+	return layers, err
+}
+
+func (r *costLayerRepository) UpdateRemaining(id int, remaining int, tx *gorm.DB) error {
+	return tx.Model(&domain.CostLayer{}).Where("id = ?", id).Update("remaining", remaining).Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepository interface {
+	FindByKey(userID int, key string) (domain.IdempotencyKey, error)
+	Claim(record domain.IdempotencyKey) (domain.IdempotencyKey, bool, error)
+	Complete(id int, statusCode int, responseBody string) error
+	Delete(id int) error
+	DeleteExpired(now time.Time) (int64, error)
+	DeleteExpiredKey(userID int, key string, now time.Time) error
+}
+
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+func (r *idempotencyKeyRepository) FindByKey(userID int, key string) (domain.IdempotencyKey, error) {
+	var record domain.IdempotencyKey
+	err := r.db.Where("user_id = ? AND `key` = ?", userID, key).First(&record).Error
+	return record, err
+}
+
+// Claim menyimpan key baru. Nilai bool false berarti key sudah dimiliki request lain,
+// sehingga dua request paralel dengan key yang sama tidak bisa sama-sama diproses.
+func (r *idempotencyKeyRepository) Claim(record domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return record, false, result.Error
+	}
+	return record, result.RowsAffected > 0, nil
+}
+
+func (r *idempotencyKeyRepository) Complete(id int, statusCode int, responseBody string) error {
+	return r.db.Model(&domain.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"response_body": responseBody,
+		}).Error
+}
+
+func (r *idempotencyKeyRepository) Delete(id int) error {
+	return r.db.Delete(&domain.IdempotencyKey{}, id).Error
+}
+
+func (r *idempotencyKeyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&domain.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteExpiredKey menghapus satu key milik user jika sudah lewat masa berlakunya
+func (r *idempotencyKeyRepository) DeleteExpiredKey(userID int, key string, now time.Time) error {
+	return r.db.Where("user_id = ? AND `key` = ? AND expires_at <= ?", userID, key, now).Delete(&domain.IdempotencyKey{}).Error
+}
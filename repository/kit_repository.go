@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type KitRepository interface {
+	FindComponents(kitID int) ([]domain.KitComponent, error)
+	FindAllComponents() ([]domain.KitComponent, error)
+	IsComponent(productID int) (bool, error)
+	ReplaceComponents(kitID int, components []domain.KitComponent) error
+	SaveAssembly(assembly domain.KitAssembly, tx *gorm.DB) (domain.KitAssembly, error)
+}
+
+type kitRepository struct {
+	db *gorm.DB
+}
+
+func NewKitRepository(db *gorm.DB) KitRepository {
+	return &kitRepository{db: db}
+}
+
+// FindComponents mengambil bill of materials kit, diurutkan berdasarkan component_id agar
+// urutan penguncian baris produk saat perakitan konsisten
+func (r *kitRepository) FindComponents(kitID int) ([]domain.KitComponent, error) {
+	var components []domain.KitComponent
+	err := r.db.Preload("Component").Where("kit_id = ?", kitID).Order("component_id asc").Find(&components).Error
+	return components, err
+}
+
+func (r *kitRepository) FindAllComponents() ([]domain.KitComponent, error) {
+	var components []domain.KitComponent
+	err := r.db.Preload("Component").Order("kit_id asc, component_id asc").Find(&components).Error
+	return components, err
+}
+
+// IsComponent memeriksa apakah produk dipakai sebagai komponen kit lain
+func (r *kitRepository) IsComponent(productID int) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.KitComponent{}).Where("component_id = ?", productID).Count(&count).Error
+	return count > 0, err
+}
+
+// ReplaceComponents mengganti seluruh bill of materials kit. components kosong berarti
+// produk tidak lagi menjadi kit.
+func (r *kitRepository) ReplaceComponents(kitID int, components []domain.KitComponent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kit_id = ?", kitID).Delete(&domain.KitComponent{}).Error; err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			return nil
+		}
+		return tx.Omit("Component").Create(&components).Error
+	})
+}
+
+func (r *kitRepository) SaveAssembly(assembly domain.KitAssembly, tx *gorm.DB) (domain.KitAssembly, error) {
+	err := tx.Create(&assembly).Error
+	return assembly, err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type ProductAttachmentRepository interface {
+	FindByProduct(productID int) ([]domain.ProductAttachment, error)
+	FindById(productID, id int) (domain.ProductAttachment, error)
+	Save(attachment domain.ProductAttachment) (domain.ProductAttachment, error)
+	Delete(productID, id int) error
+}
+
+type productAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewProductAttachmentRepository(db *gorm.DB) ProductAttachmentRepository {
+	return &productAttachmentRepository{db: db}
+}
+
+func (r *productAttachmentRepository) FindByProduct(productID int) ([]domain.ProductAttachment, error) {
+	var attachments []domain.ProductAttachment
+	err := r.db.Where("product_id = ?", productID).Order("id asc").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *productAttachmentRepository) FindById(productID, id int) (domain.ProductAttachment, error) {
+	var attachment domain.ProductAttachment
+	err := r.db.Where("product_id = ? AND id = ?", productID, id).First(&attachment).Error
+	return attachment, err
+}
+
+func (r *productAttachmentRepository) Save(attachment domain.ProductAttachment) (domain.ProductAttachment, error) {
+	err := r.db.Create(&attachment).Error
+	return attachment, err
+}
+
+func (r *productAttachmentRepository) Delete(productID, id int) error {
+	result := r.db.Where("product_id = ? AND id = ?", productID, id).Delete(&domain.ProductAttachment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type ProductBarcodeRepository interface {
+	FindByProduct(productID int) ([]domain.ProductBarcode, error)
+	Save(barcode domain.ProductBarcode) (domain.ProductBarcode, error)
+	Delete(productID, id int) error
+}
+
+type productBarcodeRepository struct {
+	db *gorm.DB
+}
+
+func NewProductBarcodeRepository(db *gorm.DB) ProductBarcodeRepository {
+	return &productBarcodeRepository{db: db}
+}
+
+func (r *productBarcodeRepository) FindByProduct(productID int) ([]domain.ProductBarcode, error) {
+	var barcodes []domain.ProductBarcode
+	err := r.db.Where("product_id = ?", productID).Order("id asc").Find(&barcodes).Error
+	return barcodes, err
+}
+
+func (r *productBarcodeRepository) Save(barcode domain.ProductBarcode) (domain.ProductBarcode, error) {
+	err := r.db.Create(&barcode).Error
+	return barcode, err
+}
+
+func (r *productBarcodeRepository) Delete(productID, id int) error {
+	result := r.db.Where("product_id = ? AND id = ?", productID, id).Delete(&domain.ProductBarcode{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -5,15 +5,34 @@ import (
 	"inventory-management-api/model/domain"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
-	FindAll() ([]domain.Product, error)
+	FindAll(filters map[string]interface{}) ([]domain.Product, error)
 	FindById(id int) (domain.Product, error)
+	FindByCode(code string) (domain.Product, error)
+	FindByCodeWithTrashed(code string) (domain.Product, error)
 	Save(product domain.Product) (domain.Product, error)
 	Update(product domain.Product) (domain.Product, error)
 	Delete(id int) error
 	SearchWithFilter(name, sort string, page, limit int) ([]domain.Product, error)
+	FindByCategoryId(categoryID int) ([]domain.Product, error)
+	FindByIdForUpdate(id int, tx *gorm.DB) (domain.Product, error)
+	FindLowStock() ([]domain.Product, error)
+	UpdateStock(id int, stock int, tx *gorm.DB) error
+	UpdateAverageCost(id int, averageCost float64, tx *gorm.DB) error
+
+	FindVariants(parentID int) ([]domain.Product, error)
+	FindAllVariants() ([]domain.Product, error)
+	HasVariants(id int) (bool, error)
+	UpdateVariantCategory(parentID int, categoryID int) error
+
+	FindTrashed() ([]domain.Product, error)
+	FindTrashedById(id int) (domain.Product, error)
+	Restore(id int) error
+	CountReferences(id int) (map[string]int, error)
+	Purge(id int) error
 }
 
 type productRepository struct {
@@ -24,15 +43,66 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 	return &productRepository{db: db}
 }
 
-func (r *productRepository) FindAll() ([]domain.Product, error) {
+func (r *productRepository) FindAll(filters map[string]interface{}) ([]domain.Product, error) {
+	query := r.db
+
+	// Filter kategori mencakup seluruh subkategorinya
+	if categoryID, ok := filters["category_id"]; ok {
+		ids, err := categorySubtreeIds(r.db, categoryID.(int))
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("category_id IN ?", ids)
+	}
+	if supplierID, ok := filters["supplier_id"]; ok {
+		query = query.Where("id IN (?)", r.db.Model(&domain.ProductSupplier{}).Select("product_id").Where("supplier_id = ?", supplierID))
+	}
+	if parentID, ok := filters["parent_id"]; ok {
+		query = query.Where("parent_id = ?", parentID)
+	}
+	if topLevel, ok := filters["top_level"]; ok && topLevel.(bool) {
+		query = query.Where("parent_id IS NULL")
+	}
+	// Setiap pasangan atribut harus cocok (AND)
+	if attributes, ok := filters["attributes"]; ok {
+		for name, value := range attributes.(map[string]string) {
+			query = query.Where("id IN (?)", r.db.Model(&domain.ProductAttribute{}).Select("product_id").Where("name = ? AND value = ?", name, value))
+		}
+	}
+
 	var products []domain.Product
-	err := r.db.Order("id asc").Find(&products).Error
+	err := query.Preload("Attributes").Preload("Attachments").Order("id asc").Find(&products).Error
 	return products, err
 }
 
 func (r *productRepository) FindById(id int) (domain.Product, error) {
 	var product domain.Product
-	err := r.db.First(&product, id).Error
+	err := r.db.Preload("BaseUnit").Preload("Units.Unit").Preload("Attributes").Preload("Barcodes").Preload("Attachments").First(&product, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Product{}, errors.New("product not found")
+	}
+	return product, err
+}
+
+// FindByCode mencari produk berdasarkan SKU atau salah satu barcode-nya
+func (r *productRepository) FindByCode(code string) (domain.Product, error) {
+	var product domain.Product
+	err := r.db.Where("sku = ?", code).
+		Or("id IN (?)", r.db.Model(&domain.ProductBarcode{}).Select("product_id").Where("code = ?", code)).
+		First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Product{}, errors.New("product not found")
+	}
+	return product, err
+}
+
+// FindByCodeWithTrashed sama seperti FindByCode tetapi ikut mencari produk di trash, dipakai
+// untuk memastikan SKU atau barcode produk yang masih bisa dipulihkan tidak dipakai ulang
+func (r *productRepository) FindByCodeWithTrashed(code string) (domain.Product, error) {
+	var product domain.Product
+	err := r.db.Unscoped().Where("sku = ?", code).
+		Or("id IN (?)", r.db.Model(&domain.ProductBarcode{}).Select("product_id").Where("code = ?", code)).
+		First(&product).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return domain.Product{}, errors.New("product not found")
 	}
@@ -52,8 +122,22 @@ func (r *productRepository) Update(product domain.Product) (domain.Product, erro
 		return domain.Product{}, errors.New("product not found")
 	}
 
-	err = r.db.Model(&existing).Updates(product).Error
-	return existing, err
+	// Pakai map agar nilai nol (serialized false, min_stock 0) tetap tersimpan. Stok tidak ikut
+	// diubah karena hanya boleh berubah lewat stock movement.
+	err = r.db.Model(&existing).Updates(map[string]interface{}{
+		"name":             product.Name,
+		"category_id":      product.CategoryID,
+		"serialized":       product.Serialized,
+		"sku":              product.SKU,
+		"min_stock":        product.MinStock,
+		"reorder_point":    product.ReorderPoint,
+		"reorder_quantity": product.ReorderQuantity,
+	}).Error
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return r.FindById(product.ID)
 }
 
 func (r *productRepository) Delete(id int) error {
@@ -109,3 +193,135 @@ func (r *productRepository) SearchWithFilter(name, sort string, page, limit int)
 	}
 	return products, nil
 }
+
+// FindByCategoryId mengambil produk dalam kategori categoryID dan seluruh subkategorinya
+func (r *productRepository) FindByCategoryId(categoryID int) ([]domain.Product, error) {
+	ids, err := categorySubtreeIds(r.db, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var products []domain.Product
+	err = r.db.Where("category_id IN ?", ids).Order("id asc").Find(&products).Error
+	return products, err
+}
+
+// FindByIdForUpdate mengambil produk sekaligus mengunci barisnya (SELECT ... FOR UPDATE)
+// sampai transaksi tx selesai, sehingga perubahan stok tidak saling menimpa.
+func (r *productRepository) FindByIdForUpdate(id int, tx *gorm.DB) (domain.Product, error) {
+	var product domain.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Product{}, errors.New("product not found")
+	}
+	return product, err
+}
+
+// FindLowStock mengambil produk yang stoknya di bawah ambang batas, yaitu nilai terbesar
+// antara min_stock dan reorder_point
+func (r *productRepository) FindLowStock() ([]domain.Product, error) {
+	var products []domain.Product
+	err := r.db.Where("stock < GREATEST(min_stock, reorder_point)").Order("id asc").Find(&products).Error
+	return products, err
+}
+
+func (r *productRepository) UpdateStock(id int, stock int, tx *gorm.DB) error {
+	return tx.Model(&domain.Product{}).Where("id = ?", id).Update("stock", stock).Error
+}
+
+func (r *productRepository) UpdateAverageCost(id int, averageCost float64, tx *gorm.DB) error {
+	return tx.Model(&domain.Product{}).Where("id = ?", id).Update("average_cost", averageCost).Error
+}
+
+func (r *productRepository) FindVariants(parentID int) ([]domain.Product, error) {
+	var products []domain.Product
+	err := r.db.Preload("Attributes").Where("parent_id = ?", parentID).Order("id asc").Find(&products).Error
+	return products, err
+}
+
+// FindAllVariants mengambil semua varian (id, parent_id, dan stok) untuk menjumlahkan stok produk induk
+func (r *productRepository) FindAllVariants() ([]domain.Product, error) {
+	var products []domain.Product
+	err := r.db.Select("id", "parent_id", "stock").Where("parent_id IS NOT NULL").Find(&products).Error
+	return products, err
+}
+
+func (r *productRepository) HasVariants(id int) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Product{}).Where("parent_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateVariantCategory menyamakan kategori semua varian dengan kategori produk induknya
+func (r *productRepository) UpdateVariantCategory(parentID int, categoryID int) error {
+	return r.db.Model(&domain.Product{}).Where("parent_id = ?", parentID).Update("category_id", categoryID).Error
+}
+
+// FindTrashed mengambil produk yang sudah dihapus (soft delete), terbaru lebih dulu
+func (r *productRepository) FindTrashed() ([]domain.Product, error) {
+	var products []domain.Product
+	err := r.db.Unscoped().Preload("Attributes").Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&products).Error
+	return products, err
+}
+
+func (r *productRepository) FindTrashedById(id int) (domain.Product, error) {
+	var product domain.Product
+	err := r.db.Unscoped().Preload("Attachments").Where("deleted_at IS NOT NULL").First(&product, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Product{}, errors.New("product not found")
+	}
+	return product, err
+}
+
+func (r *productRepository) Restore(id int) error {
+	return r.db.Unscoped().Model(&domain.Product{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// CountReferences menghitung transaksi, varian, dan kit yang masih merujuk produk id
+func (r *productRepository) CountReferences(id int) (map[string]int, error) {
+	return countReferences(r.db, id, []reference{
+		{Name: "stock_movements", Model: &domain.StockMovement{}, Where: "product_id = ?"},
+		{Name: "reservations", Model: &domain.Reservation{}, Where: "product_id = ?"},
+		{Name: "purchase_order_lines", Model: &domain.PurchaseOrderLine{}, Where: "product_id = ?"},
+		{Name: "sales_order_lines", Model: &domain.SalesOrderLine{}, Where: "product_id = ?"},
+		{Name: "stocktake_lines", Model: &domain.StocktakeLine{}, Where: "product_id = ?"},
+		{Name: "variants", Model: &domain.Product{}, Where: "parent_id = ?"},
+		{Name: "kit_components", Model: &domain.KitComponent{}, Where: "component_id = ?"},
+		{Name: "kit_assemblies", Model: &domain.KitAssembly{}, Where: "kit_id = ?"},
+	})
+}
+
+// Purge menghapus permanen produk beserta data katalog miliknya (atribut, barcode, satuan,
+// pemasok, stok per gudang, alert, lampiran, dan bill of materials kit). Berkas lampiran di
+// storage dihapus oleh service. Pastikan CountReferences kosong sebelum memanggilnya.
+func (r *productRepository) Purge(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		owned := []interface{}{
+			&domain.ProductAttribute{},
+			&domain.ProductBarcode{},
+			&domain.ProductUnit{},
+			&domain.ProductSupplier{},
+			&domain.ProductStock{},
+			&domain.StockAlert{},
+			&domain.StockSnapshot{},
+			&domain.ProductAttachment{},
+		}
+		for _, model := range owned {
+			if err := tx.Where("product_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("kit_id = ?", id).Delete(&domain.KitComponent{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&domain.Product{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("product not found")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductStockRepository interface {
+	FindAll() ([]domain.ProductStock, error)
+	FindByProductId(productID int) ([]domain.ProductStock, error)
+	FindByProductAndWarehouse(productID, warehouseID int) (domain.ProductStock, error)
+	FindOrCreateForUpdate(productID, warehouseID int, tx *gorm.DB) (domain.ProductStock, error)
+	FindByProductIdForUpdate(productID int, tx *gorm.DB) ([]domain.ProductStock, error)
+	UpdateStock(id int, stock int, tx *gorm.DB) error
+}
+
+type productStockRepository struct {
+	db *gorm.DB
+}
+
+func NewProductStockRepository(db *gorm.DB) ProductStockRepository {
+	return &productStockRepository{db: db}
+}
+
+func (r *productStockRepository) FindAll() ([]domain.ProductStock, error) {
+	var stocks []domain.ProductStock
+	err := r.db.Order("product_id asc, warehouse_id asc").Find(&stocks).Error
+	return stocks, err
+}
+
+func (r *productStockRepository) FindByProductId(productID int) ([]domain.ProductStock, error) {
+	var stocks []domain.ProductStock
+	err := r.db.Preload("Warehouse").
+		Where("product_id = ?", productID).
+		Order("warehouse_id asc").
+		Find(&stocks).Error
+	return stocks, err
+}
+
+// FindByProductAndWarehouse mengambil saldo produk di satu gudang, saldo 0 jika belum ada
+func (r *productStockRepository) FindByProductAndWarehouse(productID, warehouseID int) (domain.ProductStock, error) {
+	var stock domain.ProductStock
+	err := r.db.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ProductStock{ProductID: productID, WarehouseID: warehouseID}, nil
+	}
+	return stock, err
+}
+
+// FindByProductIdForUpdate mengunci semua saldo gudang milik produk di dalam transaksi tx
+func (r *productStockRepository) FindByProductIdForUpdate(productID int, tx *gorm.DB) ([]domain.ProductStock, error) {
+	var stocks []domain.ProductStock
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ?", productID).
+		Order("warehouse_id asc").
+		Find(&stocks).Error
+	return stocks, err
+}
+
+// FindOrCreateForUpdate mengunci saldo produk di gudang tertentu, membuat baris baru
+// dengan stok 0 jika produk belum pernah ada di gudang tersebut.
+func (r *productStockRepository) FindOrCreateForUpdate(productID, warehouseID int, tx *gorm.DB) (domain.ProductStock, error) {
+	var stock domain.ProductStock
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
+		First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		stock = domain.ProductStock{ProductID: productID, WarehouseID: warehouseID}
+		err = tx.Create(&stock).Error
+	}
+	return stock, err
+}
+
+func (r *productStockRepository) UpdateStock(id int, stock int, tx *gorm.DB) error {
+	return tx.Model(&domain.ProductStock{}).Where("id = ?", id).Update("stock", stock).Error
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepository interface {
+	FindAll(filters map[string]interface{}) ([]domain.PurchaseOrder, error)
+	FindById(id int) (domain.PurchaseOrder, error)
+	FindByIdForUpdate(id int, tx *gorm.DB) (domain.PurchaseOrder, error)
+	Save(order domain.PurchaseOrder, tx *gorm.DB) (domain.PurchaseOrder, error)
+	Update(order domain.PurchaseOrder, tx *gorm.DB) error
+	ReplaceLines(orderID int, lines []domain.PurchaseOrderLine, tx *gorm.DB) error
+	UpdateStatus(id int, status string, tx *gorm.DB) error
+	UpdateLineReceived(lineID int, receivedQuantity int, tx *gorm.DB) error
+	SumOpenByProduct() (map[int]int, error)
+}
+
+type purchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB) PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: db}
+}
+
+func withPurchaseOrderLines(db *gorm.DB) *gorm.DB {
+	return db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id asc")
+	}).Preload("Lines.Product")
+}
+
+func (r *purchaseOrderRepository) FindAll(filters map[string]interface{}) ([]domain.PurchaseOrder, error) {
+	query := withPurchaseOrderLines(r.db)
+
+	if status, ok := filters["status"]; ok {
+		query = query.Where("status = ?", status)
+	}
+
+	var orders []domain.PurchaseOrder
+	err := query.Order("id desc").Find(&orders).Error
+	return orders, err
+}
+
+func (r *purchaseOrderRepository) FindById(id int) (domain.PurchaseOrder, error) {
+	var order domain.PurchaseOrder
+	err := withPurchaseOrderLines(r.db).First(&order, id).Error
+	return order, err
+}
+
+func (r *purchaseOrderRepository) FindByIdForUpdate(id int, tx *gorm.DB) (domain.PurchaseOrder, error) {
+	var order domain.PurchaseOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id asc")
+	}).First(&order, id).Error
+	return order, err
+}
+
+func (r *purchaseOrderRepository) Save(order domain.PurchaseOrder, tx *gorm.DB) (domain.PurchaseOrder, error) {
+	err := tx.Omit("Warehouse", "Lines.Product").Create(&order).Error
+	return order, err
+}
+
+func (r *purchaseOrderRepository) Update(order domain.PurchaseOrder, tx *gorm.DB) error {
+	return tx.Model(&domain.PurchaseOrder{}).
+		Where("id = ?", order.ID).
+		Updates(map[string]interface{}{
+			"supplier":      order.Supplier,
+			"warehouse_id":  order.WarehouseID,
+			"expected_date": order.ExpectedDate,
+			"note":          order.Note,
+		}).Error
+}
+
+// ReplaceLines mengganti seluruh baris PO, hanya dipakai selama PO masih draft
+func (r *purchaseOrderRepository) ReplaceLines(orderID int, lines []domain.PurchaseOrderLine, tx *gorm.DB) error {
+	if err := tx.Where("purchase_order_id = ?", orderID).Delete(&domain.PurchaseOrderLine{}).Error; err != nil {
+		return err
+	}
+	for i := range lines {
+		lines[i].PurchaseOrderID = orderID
+	}
+	return tx.Omit("Product").Create(&lines).Error
+}
+
+func (r *purchaseOrderRepository) UpdateStatus(id int, status string, tx *gorm.DB) error {
+	return tx.Model(&domain.PurchaseOrder{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *purchaseOrderRepository) UpdateLineReceived(lineID int, receivedQuantity int, tx *gorm.DB) error {
+	return tx.Model(&domain.PurchaseOrderLine{}).
+		Where("id = ?", lineID).
+		Update("received_quantity", receivedQuantity).Error
+}
+
+// SumOpenByProduct menjumlahkan kuantitas yang sudah dipesan tetapi belum diterima
+// (PO berstatus ordered atau partially_received) per product_id
+func (r *purchaseOrderRepository) SumOpenByProduct() (map[int]int, error) {
+	var rows []struct {
+		ProductID int
+		Total     int
+	}
+	err := r.db.Model(&domain.PurchaseOrderLine{}).
+		Select("purchase_order_lines.product_id, SUM(GREATEST(purchase_order_lines.ordered_quantity - purchase_order_lines.received_quantity, 0)) AS total").
+		Joins("JOIN purchase_orders ON purchase_orders.id = purchase_order_lines.purchase_order_id").
+		Where("purchase_orders.status IN ?", []string{"ordered", "partially_received"}).
+		Group("purchase_order_lines.product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := map[int]int{}
+	for _, row := range rows {
+		totals[row.ProductID] = row.Total
+	}
+	return totals, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import "gorm.io/gorm"
+
+// reference adalah tabel yang menyimpan ID sebuah baris, misalnya stock_movements.product_id.
+// Dipakai untuk menolak purge selama baris tersebut masih dirujuk.
+type reference struct {
+	Name  string
+	Model interface{}
+	Where string
+}
+
+// countReferences menghitung rujukan ke id pada setiap tabel refs. Hanya tabel yang masih
+// merujuk (jumlah > 0) yang dikembalikan, termasuk baris yang sudah di-soft delete.
+func countReferences(db *gorm.DB, id int, refs []reference) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, ref := range refs {
+		var count int64
+		if err := db.Unscoped().Model(ref.Model).Where(ref.Where, id).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			counts[ref.Name] = int(count)
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReservationRepository interface {
+	FindAll(filters map[string]interface{}) ([]domain.Reservation, error)
+	FindById(id int) (domain.Reservation, error)
+	FindByIdForUpdate(id int, tx *gorm.DB) (domain.Reservation, error)
+	Save(reservation domain.Reservation, tx *gorm.DB) (domain.Reservation, error)
+	UpdateQuantity(id int, quantity int, status string, tx *gorm.DB) error
+	SumActive(productID, warehouseID int, now time.Time, tx *gorm.DB) (int, error)
+	SumActiveByProduct(now time.Time) (map[int]int, error)
+	SumActiveByWarehouse(productID int, now time.Time) (map[int]int, error)
+	ReleaseExpired(now time.Time) (int64, error)
+}
+
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+// activeReservations membatasi query ke reservasi yang masih menahan stok pada waktu now.
+// Reservasi yang lewat expires_at dianggap lepas walaupun statusnya belum diperbarui.
+func activeReservations(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Model(&domain.Reservation{}).
+		Where("status = ? AND (expires_at IS NULL OR expires_at > ?)", "active", now)
+}
+
+func (r *reservationRepository) FindAll(filters map[string]interface{}) ([]domain.Reservation, error) {
+	query := r.db
+
+	if productID, ok := filters["product_id"]; ok {
+		query = query.Where("product_id = ?", productID)
+	}
+	if warehouseID, ok := filters["warehouse_id"]; ok {
+		query = query.Where("warehouse_id = ?", warehouseID)
+	}
+	if status, ok := filters["status"]; ok {
+		query = query.Where("status = ?", status)
+	}
+
+	var reservations []domain.Reservation
+	err := query.Order("id desc").Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *reservationRepository) FindById(id int) (domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := r.db.First(&reservation, id).Error
+	return reservation, err
+}
+
+func (r *reservationRepository) FindByIdForUpdate(id int, tx *gorm.DB) (domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reservation, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return reservation, errors.New("reservation not found")
+	}
+	return reservation, err
+}
+
+func (r *reservationRepository) Save(reservation domain.Reservation, tx *gorm.DB) (domain.Reservation, error) {
+	err := tx.Omit("Product", "Warehouse").Create(&reservation).Error
+	return reservation, err
+}
+
+func (r *reservationRepository) UpdateQuantity(id int, quantity int, status string, tx *gorm.DB) error {
+	return tx.Model(&domain.Reservation{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"quantity": quantity,
+			"status":   status,
+		}).Error
+}
+
+func (r *reservationRepository) SumActive(productID, warehouseID int, now time.Time, tx *gorm.DB) (int, error) {
+	var total int
+	err := activeReservations(tx, now).
+		Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// SumActiveByProduct mengembalikan total stok yang ditahan per product_id
+func (r *reservationRepository) SumActiveByProduct(now time.Time) (map[int]int, error) {
+	var rows []struct {
+		ProductID int
+		Total     int
+	}
+	err := activeReservations(r.db, now).
+		Select("product_id, SUM(quantity) AS total").
+		Group("product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := map[int]int{}
+	for _, row := range rows {
+		totals[row.ProductID] = row.Total
+	}
+	return totals, nil
+}
+
+// SumActiveByWarehouse mengembalikan stok satu produk yang ditahan per warehouse_id
+func (r *reservationRepository) SumActiveByWarehouse(productID int, now time.Time) (map[int]int, error) {
+	var rows []struct {
+		WarehouseID int
+		Total       int
+	}
+	err := activeReservations(r.db, now).
+		Where("product_id = ?", productID).
+		Select("warehouse_id, SUM(quantity) AS total").
+		Group("warehouse_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := map[int]int{}
+	for _, row := range rows {
+		totals[row.WarehouseID] = row.Total
+	}
+	return totals, nil
+}
+
+func (r *reservationRepository) ReleaseExpired(now time.Time) (int64, error) {
+	result := r.db.Model(&domain.Reservation{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", "active", now).
+		Update("status", "expired")
+	return result.RowsAffected, result.Error
+}
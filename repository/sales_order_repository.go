@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SalesOrderRepository interface {
+	FindAll(filters map[string]interface{}) ([]domain.SalesOrder, error)
+	FindById(id int) (domain.SalesOrder, error)
+	FindByIdForUpdate(id int, tx *gorm.DB) (domain.SalesOrder, error)
+	Save(order domain.SalesOrder, tx *gorm.DB) (domain.SalesOrder, error)
+	Update(order domain.SalesOrder, tx *gorm.DB) error
+	ReplaceLines(orderID int, lines []domain.SalesOrderLine, tx *gorm.DB) error
+	UpdateStatus(id int, status string, tx *gorm.DB) error
+	UpdateLine(line domain.SalesOrderLine, tx *gorm.DB) error
+}
+
+type salesOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewSalesOrderRepository(db *gorm.DB) SalesOrderRepository {
+	return &salesOrderRepository{db: db}
+}
+
+func withSalesOrderLines(db *gorm.DB) *gorm.DB {
+	return db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id asc")
+	}).Preload("Lines.Product").Preload("Lines.Reservation")
+}
+
+func (r *salesOrderRepository) FindAll(filters map[string]interface{}) ([]domain.SalesOrder, error) {
+	query := withSalesOrderLines(r.db)
+
+	if status, ok := filters["status"]; ok {
+		query = query.Where("status = ?", status)
+	}
+
+	var orders []domain.SalesOrder
+	err := query.Order("id desc").Find(&orders).Error
+	return orders, err
+}
+
+func (r *salesOrderRepository) FindById(id int) (domain.SalesOrder, error) {
+	var order domain.SalesOrder
+	err := withSalesOrderLines(r.db).First(&order, id).Error
+	return order, err
+}
+
+func (r *salesOrderRepository) FindByIdForUpdate(id int, tx *gorm.DB) (domain.SalesOrder, error) {
+	var order domain.SalesOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id asc")
+	}).First(&order, id).Error
+	return order, err
+}
+
+func (r *salesOrderRepository) Save(order domain.SalesOrder, tx *gorm.DB) (domain.SalesOrder, error) {
+	err := tx.Omit("Warehouse").Create(&order).Error
+	return order, err
+}
+
+func (r *salesOrderRepository) Update(order domain.SalesOrder, tx *gorm.DB) error {
+	return tx.Model(&domain.SalesOrder{}).
+		Where("id = ?", order.ID).
+		Updates(map[string]interface{}{
+			"customer":     order.Customer,
+			"warehouse_id": order.WarehouseID,
+			"note":         order.Note,
+		}).Error
+}
+
+// ReplaceLines mengganti seluruh baris order, hanya dipakai selama order masih draft
+func (r *salesOrderRepository) ReplaceLines(orderID int, lines []domain.SalesOrderLine, tx *gorm.DB) error {
+	if err := tx.Where("sales_order_id = ?", orderID).Delete(&domain.SalesOrderLine{}).Error; err != nil {
+		return err
+	}
+	for i := range lines {
+		lines[i].SalesOrderID = orderID
+	}
+	return tx.Omit("Product", "Reservation").Create(&lines).Error
+}
+
+func (r *salesOrderRepository) UpdateStatus(id int, status string, tx *gorm.DB) error {
+	return tx.Model(&domain.SalesOrder{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *salesOrderRepository) UpdateLine(line domain.SalesOrderLine, tx *gorm.DB) error {
+	return tx.Model(&domain.SalesOrderLine{}).
+		Where("id = ?", line.ID).
+		Updates(map[string]interface{}{
+			"shipped_quantity": line.ShippedQuantity,
+			"reservation_id":   line.ReservationID,
+		}).Error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SerialNumberRepository interface {
+	FindBySerial(serial string) (domain.SerialNumber, error)
+	FindBySerialForUpdate(serial string, tx *gorm.DB) (domain.SerialNumber, error)
+	Save(serialNumber domain.SerialNumber, tx *gorm.DB) (domain.SerialNumber, error)
+	CountByProduct(productID int) (int, error)
+}
+
+type serialNumberRepository struct {
+	db *gorm.DB
+}
+
+func NewSerialNumberRepository(db *gorm.DB) SerialNumberRepository {
+	return &serialNumberRepository{db: db}
+}
+
+func (r *serialNumberRepository) FindBySerial(serial string) (domain.SerialNumber, error) {
+	var sn domain.SerialNumber
+	err := r.db.Where("serial = ?", serial).First(&sn).Error
+	return sn, err
+}
+
+func (r *serialNumberRepository) FindBySerialForUpdate(serial string, tx *gorm.DB) (domain.SerialNumber, error) {
+	var sn domain.SerialNumber
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("serial = ?", serial).First(&sn).Error
+	return sn, err
+}
+
+// Save membuat serial number baru atau memperbarui status/lokasinya jika sudah ada
+func (r *serialNumberRepository) Save(sn domain.SerialNumber, tx *gorm.DB) (domain.SerialNumber, error) {
+	err := tx.Omit("Product", "Warehouse").Save(&sn).Error
+	return sn, err
+}
+
+// CountByProduct menghitung serial number produk dengan status apa pun
+func (r *serialNumberRepository) CountByProduct(productID int) (int, error) {
+	var count int64
+	err := r.db.Model(&domain.SerialNumber{}).Where("product_id = ?", productID).Count(&count).Error
+	return int(count), err
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockAlertRepository interface {
+	FindAll(filters map[string]interface{}) ([]domain.StockAlert, error)
+	FindOpenByProduct(productID int, tx *gorm.DB) (domain.StockAlert, bool, error)
+	FindOpen() (map[int]domain.StockAlert, error)
+	Save(alert domain.StockAlert, tx *gorm.DB) (domain.StockAlert, error)
+	Resolve(id int, movementID int, resolvedAt time.Time, tx *gorm.DB) error
+}
+
+type stockAlertRepository struct {
+	db *gorm.DB
+}
+
+func NewStockAlertRepository(db *gorm.DB) StockAlertRepository {
+	return &stockAlertRepository{db: db}
+}
+
+func (r *stockAlertRepository) FindAll(filters map[string]interface{}) ([]domain.StockAlert, error) {
+	query := r.db.Preload("Product")
+
+	if productID, ok := filters["product_id"]; ok {
+		query = query.Where("product_id = ?", productID)
+	}
+	if status, ok := filters["status"]; ok {
+		query = query.Where("status = ?", status)
+	}
+
+	var alerts []domain.StockAlert
+	err := query.Order("id desc").Find(&alerts).Error
+	return alerts, err
+}
+
+// FindOpenByProduct mencari alert yang masih open untuk produk. Pemanggil sudah memegang
+// lock baris produk, jadi pengecekan ini tidak balapan dengan movement lain.
+func (r *stockAlertRepository) FindOpenByProduct(productID int, tx *gorm.DB) (domain.StockAlert, bool, error) {
+	var alert domain.StockAlert
+	err := tx.Where("product_id = ? AND status = ?", productID, "open").First(&alert).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.StockAlert{}, false, nil
+	}
+	if err != nil {
+		return domain.StockAlert{}, false, err
+	}
+	return alert, true, nil
+}
+
+// FindOpen mengembalikan alert open per product_id
+func (r *stockAlertRepository) FindOpen() (map[int]domain.StockAlert, error) {
+	var alerts []domain.StockAlert
+	if err := r.db.Where("status = ?", "open").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	result := map[int]domain.StockAlert{}
+	for _, a := range alerts {
+		result[a.ProductID] = a
+	}
+	return result, nil
+}
+
+func (r *stockAlertRepository) Save(alert domain.StockAlert, tx *gorm.DB) (domain.StockAlert, error) {
+	err := tx.Omit("Product").Create(&alert).Error
+	return alert, err
+}
+
+func (r *stockAlertRepository) Resolve(id int, movementID int, resolvedAt time.Time, tx *gorm.DB) error {
+	return tx.Model(&domain.StockAlert{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":               "resolved",
+			"resolved_movement_id": movementID,
+			"resolved_at":          resolvedAt,
+		}).Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type StockDocumentRepository interface {
+	FindAll() ([]domain.StockDocument, error)
+	FindById(id int) (domain.StockDocument, error)
+	Save(document domain.StockDocument, tx *gorm.DB) (domain.StockDocument, error)
+}
+
+type stockDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewStockDocumentRepository(db *gorm.DB) StockDocumentRepository {
+	return &stockDocumentRepository{db: db}
+}
+
+func (r *stockDocumentRepository) withMovements() *gorm.DB {
+	return r.db.
+		Preload("Movements", func(db *gorm.DB) *gorm.DB { return db.Order("id asc") }).
+		Preload("Movements.Lots.Lot").
+		Preload("Movements.Serials.SerialNumber")
+}
+
+func (r *stockDocumentRepository) FindAll() ([]domain.StockDocument, error) {
+	var documents []domain.StockDocument
+	err := r.withMovements().Order("id desc").Find(&documents).Error
+	return documents, err
+}
+
+func (r *stockDocumentRepository) FindById(id int) (domain.StockDocument, error) {
+	var document domain.StockDocument
+	err := r.withMovements().First(&document, id).Error
+	return document, err
+}
+
+func (r *stockDocumentRepository) Save(document domain.StockDocument, tx *gorm.DB) (domain.StockDocument, error) {
+	err := tx.Create(&document).Error
+	return document, err
+}
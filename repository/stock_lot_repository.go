@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockLotRepository interface {
+	FindAll(filters map[string]interface{}) ([]domain.StockLot, error)
+	FindExpiring(before time.Time, filters map[string]interface{}) ([]domain.StockLot, error)
+	FindByIdForUpdate(id int, tx *gorm.DB) (domain.StockLot, error)
+	FindByNumberForUpdate(productID, warehouseID int, lotNumber string, tx *gorm.DB) (domain.StockLot, error)
+	FindOrCreateForUpdate(productID, warehouseID int, lotNumber string, expiryDate *time.Time, tx *gorm.DB) (domain.StockLot, bool, error)
+	FindAvailableForUpdate(productID, warehouseID int, today time.Time, tx *gorm.DB) ([]domain.StockLot, error)
+	SumStock(productID, warehouseID int, tx *gorm.DB) (int, error)
+	UpdateStock(id int, stock int, tx *gorm.DB) error
+}
+
+type stockLotRepository struct {
+	db *gorm.DB
+}
+
+func NewStockLotRepository(db *gorm.DB) StockLotRepository {
+	return &stockLotRepository{db: db}
+}
+
+func (r *stockLotRepository) FindAll(filters map[string]interface{}) ([]domain.StockLot, error) {
+	query := r.db.Where("stock > 0")
+
+	if productID, ok := filters["product_id"]; ok {
+		query = query.Where("product_id = ?", productID)
+	}
+	if warehouseID, ok := filters["warehouse_id"]; ok {
+		query = query.Where("warehouse_id = ?", warehouseID)
+	}
+
+	var lots []domain.StockLot
+	err := query.Order("expiry_date IS NULL, expiry_date asc, id asc").Find(&lots).Error
+	return lots, err
+}
+
+// FindExpiring mengambil lot yang masih punya stok dan kedaluwarsa paling lambat pada tanggal before
+// (termasuk yang sudah lewat tanggal kedaluwarsanya).
+func (r *stockLotRepository) FindExpiring(before time.Time, filters map[string]interface{}) ([]domain.StockLot, error) {
+	query := r.db.Where("stock > 0 AND expiry_date IS NOT NULL AND expiry_date <= ?", before)
+
+	if productID, ok := filters["product_id"]; ok {
+		query = query.Where("product_id = ?", productID)
+	}
+	if warehouseID, ok := filters["warehouse_id"]; ok {
+		query = query.Where("warehouse_id = ?", warehouseID)
+	}
+
+	var lots []domain.StockLot
+	err := query.Order("expiry_date asc, id asc").Find(&lots).Error
+	return lots, err
+}
+
+func (r *stockLotRepository) FindByIdForUpdate(id int, tx *gorm.DB) (domain.StockLot, error) {
+	var lot domain.StockLot
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&lot, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.StockLot{}, errors.New("lot not found")
+	}
+	return lot, err
+}
+
+func (r *stockLotRepository) FindByNumberForUpdate(productID, warehouseID int, lotNumber string, tx *gorm.DB) (domain.StockLot, error) {
+	var lot domain.StockLot
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND warehouse_id = ? AND lot_number = ?", productID, warehouseID, lotNumber).
+		First(&lot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.StockLot{}, errors.New("lot not found")
+	}
+	return lot, err
+}
+
+// FindOrCreateForUpdate mengunci lot berdasarkan nomornya atau membuat lot baru dengan stok 0.
+// Nilai bool bernilai true jika lot baru saja dibuat.
+func (r *stockLotRepository) FindOrCreateForUpdate(productID, warehouseID int, lotNumber string, expiryDate *time.Time, tx *gorm.DB) (domain.StockLot, bool, error) {
+	lot, err := r.FindByNumberForUpdate(productID, warehouseID, lotNumber, tx)
+	if err == nil {
+		return lot, false, nil
+	}
+	if err.Error() != "lot not found" {
+		return domain.StockLot{}, false, err
+	}
+
+	lot = domain.StockLot{
+		ProductID:   productID,
+		WarehouseID: warehouseID,
+		LotNumber:   lotNumber,
+		ExpiryDate:  expiryDate,
+	}
+	err = tx.Create(&lot).Error
+	return lot, true, err
+}
+
+// FindAvailableForUpdate mengunci lot yang masih punya stok dan belum kedaluwarsa,
+// diurutkan first-expired-first-out (lot tanpa tanggal kedaluwarsa paling akhir).
+func (r *stockLotRepository) FindAvailableForUpdate(productID, warehouseID int, today time.Time, tx *gorm.DB) ([]domain.StockLot, error) {
+	var lots []domain.StockLot
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND warehouse_id = ? AND stock > 0", productID, warehouseID).
+		Where("expiry_date IS NULL OR expiry_date >= ?", today).
+		Order("expiry_date IS NULL, expiry_date asc, id asc").
+		Find(&lots).Error
+	return lots, err
+}
+
+func (r *stockLotRepository) SumStock(productID, warehouseID int, tx *gorm.DB) (int, error) {
+	var total int
+	err := tx.Model(&domain.StockLot{}).
+		Select("COALESCE(SUM(stock), 0)").
+		Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *stockLotRepository) UpdateStock(id int, stock int, tx *gorm.DB) error {
+	return tx.Model(&domain.StockLot{}).Where("id = ?", id).Update("stock", stock).Error
+}
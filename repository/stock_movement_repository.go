@@ -2,16 +2,39 @@ package repository
 
 import (
 	"inventory-management-api/model/domain"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StockMovementRepository interface {
 	FindAll() ([]domain.StockMovement, error)
 	FindById(id int) (domain.StockMovement, error)
 	Save(movement domain.StockMovement, tx *gorm.DB) (domain.StockMovement, error)
-	Delete(id int) error
+	FindByIdForUpdate(id int, tx *gorm.DB) (domain.StockMovement, error)
+	MarkReversed(id int, reversalID int, tx *gorm.DB) error
+	FindBySerialNumberId(serialNumberID int) ([]domain.StockMovement, error)
+	FindByReference(reference string) ([]domain.StockMovement, error)
 	FindByMonth(month string, filters map[string]interface{}) ([]domain.StockMovement, error)
+	ExistsByProduct(productID int) (bool, error)
+	SumOutboundByProduct(since time.Time) (map[int]int, error)
+	SumValuation(before time.Time) ([]StockValuation, error)
+	SumBalances(after *time.Time, before time.Time, productID int) ([]WarehouseBalance, error)
+}
+
+// WarehouseBalance adalah perubahan saldo satu produk di satu gudang hasil penjumlahan movement
+type WarehouseBalance struct {
+	ProductID   int
+	WarehouseID int
+	Quantity    int
+}
+
+// StockValuation adalah saldo quantity dan nilai persediaan satu produk hasil penjumlahan movement
+type StockValuation struct {
+	ProductID int
+	Quantity  int
+	Value     float64
 }
 
 type stockMovementRepository struct {
@@ -24,13 +47,13 @@ func NewStockMovementRepository(db *gorm.DB) StockMovementRepository {
 
 func (r *stockMovementRepository) FindAll() ([]domain.StockMovement, error) {
 	var movements []domain.StockMovement
-	err := r.db.Order("id desc").Find(&movements).Error
+	err := r.db.Preload("Lots.Lot").Preload("Serials.SerialNumber").Preload("Unit").Order("id desc").Find(&movements).Error
 	return movements, err
 }
 
 func (r *stockMovementRepository) FindById(id int) (domain.StockMovement, error) {
 	var m domain.StockMovement
-	err := r.db.First(&m, id).Error
+	err := r.db.Preload("Lots.Lot").Preload("Serials.SerialNumber").Preload("Unit").First(&m, id).Error
 	return m, err
 }
 
@@ -39,13 +62,30 @@ func (r *stockMovementRepository) Save(m domain.StockMovement, tx *gorm.DB) (dom
 	return m, err
 }
 
-func (r *stockMovementRepository) Delete(id int) error {
-	return r.db.Delete(&domain.StockMovement{}, id).Error
+func (r *stockMovementRepository) FindByIdForUpdate(id int, tx *gorm.DB) (domain.StockMovement, error) {
+	var m domain.StockMovement
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lots.Lot").Preload("Serials.SerialNumber").Preload("Unit").First(&m, id).Error
+	return m, err
+}
+
+func (r *stockMovementRepository) MarkReversed(id int, reversalID int, tx *gorm.DB) error {
+	return tx.Model(&domain.StockMovement{}).Where("id = ?", id).Update("reversal_id", reversalID).Error
+}
+
+// FindBySerialNumberId mengambil riwayat movement satu unit serial, urut dari yang paling lama
+func (r *stockMovementRepository) FindBySerialNumberId(serialNumberID int) ([]domain.StockMovement, error) {
+	var movements []domain.StockMovement
+	err := r.db.Preload("Lots.Lot").Preload("Serials.SerialNumber").Preload("Unit").
+		Joins("JOIN stock_movement_serials ON stock_movement_serials.stock_movement_id = stock_movements.id").
+		Where("stock_movement_serials.serial_number_id = ?", serialNumberID).
+		Order("stock_movements.created_at asc, stock_movements.id asc").
+		Find(&movements).Error
+	return movements, err
 }
 
 // ✅ Fleksibel: Jika month kosong, maka tidak difilter berdasarkan bulan
 func (r *stockMovementRepository) FindByMonth(month string, filters map[string]interface{}) ([]domain.StockMovement, error) {
-	query := r.db
+	query := r.db.Preload("Lots.Lot").Preload("Serials.SerialNumber").Preload("Unit")
 
 	if month != "" {
 		query = query.Where("DATE_FORMAT(created_at, '%Y-%m') = ?", month)
@@ -57,11 +97,109 @@ func (r *stockMovementRepository) FindByMonth(month string, filters map[string]i
 	if userID, ok := filters["user_id"]; ok {
 		query = query.Where("user_id = ?", userID)
 	}
+	if warehouseID, ok := filters["warehouse_id"]; ok {
+		query = query.Where("(warehouse_id = ? OR to_warehouse_id = ?)", warehouseID, warehouseID)
+	}
 	if movementType, ok := filters["type"]; ok {
 		query = query.Where("type = ?", movementType)
 	}
+	if supplierID, ok := filters["supplier_id"]; ok {
+		query = query.Where("product_id IN (?)", r.db.Model(&domain.ProductSupplier{}).Select("product_id").Where("supplier_id = ?", supplierID))
+	}
 
 	var movements []domain.StockMovement
 	err := query.Order("created_at desc").Find(&movements).Error
 	return movements, err
 }
+
+// FindByReference mengambil movement dari satu dokumen sumber (misalnya "purchase-order:3"), urut dari yang paling lama
+func (r *stockMovementRepository) FindByReference(reference string) ([]domain.StockMovement, error) {
+	var movements []domain.StockMovement
+	err := r.db.Preload("Lots.Lot").Preload("Serials.SerialNumber").Preload("Unit").
+		Where("reference = ?", reference).
+		Order("id asc").
+		Find(&movements).Error
+	return movements, err
+}
+
+func (r *stockMovementRepository) ExistsByProduct(productID int) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.StockMovement{}).Where("product_id = ?", productID).Count(&count).Error
+	return count > 0, err
+}
+
+// SumOutboundByProduct menjumlahkan pemakaian (movement "out") sejak waktu since per
+// product_id. Movement yang sudah dibalik maupun movement pembalik tidak dihitung.
+func (r *stockMovementRepository) SumOutboundByProduct(since time.Time) (map[int]int, error) {
+	var rows []struct {
+		ProductID int
+		Total     int
+	}
+	err := r.db.Model(&domain.StockMovement{}).
+		Select("product_id, SUM(quantity) AS total").
+		Where("type = ? AND created_at >= ?", "out", since).
+		Where("reversal_id IS NULL AND reversal_of_id IS NULL").
+		Group("product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := map[int]int{}
+	for _, row := range rows {
+		totals[row.ProductID] = row.Total
+	}
+	return totals, nil
+}
+
+// SumValuation menjumlahkan quantity dan nilai semua movement sebelum waktu before per
+// produk. Movement masuk menambah, keluar mengurangi, adjust mengikuti tanda quantity,
+// dan transfer tidak berpengaruh.
+func (r *stockMovementRepository) SumValuation(before time.Time) ([]StockValuation, error) {
+	var rows []StockValuation
+	err := r.db.Model(&domain.StockMovement{}).
+		Select(`product_id,
+			SUM(CASE type WHEN 'in' THEN quantity WHEN 'out' THEN -quantity WHEN 'adjust' THEN quantity ELSE 0 END) AS quantity,
+			SUM(CASE type WHEN 'in' THEN total_cost WHEN 'out' THEN -total_cost WHEN 'adjust' THEN SIGN(quantity) * total_cost ELSE 0 END) AS value`).
+		Where("created_at < ?", before).
+		Group("product_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// SumBalances menjumlahkan perubahan saldo per produk dan gudang dari movement dengan
+// created_at >= after (jika diisi) dan < before. productID 0 berarti semua produk.
+// Transfer mengurangi gudang asal dan menambah gudang tujuan.
+func (r *stockMovementRepository) SumBalances(after *time.Time, before time.Time, productID int) ([]WarehouseBalance, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&domain.StockMovement{}).Where("created_at < ?", before)
+		if after != nil {
+			db = db.Where("created_at >= ?", *after)
+		}
+		if productID != 0 {
+			db = db.Where("product_id = ?", productID)
+		}
+		return db
+	}
+
+	var outgoing []WarehouseBalance
+	err := r.db.Scopes(scope).
+		Select("product_id, warehouse_id, SUM(CASE type WHEN 'in' THEN quantity WHEN 'adjust' THEN quantity ELSE -quantity END) AS quantity").
+		Group("product_id, warehouse_id").
+		Scan(&outgoing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var incoming []WarehouseBalance
+	err = r.db.Scopes(scope).
+		Select("product_id, to_warehouse_id AS warehouse_id, SUM(quantity) AS quantity").
+		Where("type = ? AND to_warehouse_id IS NOT NULL", "transfer").
+		Group("product_id, to_warehouse_id").
+		Scan(&incoming).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return append(outgoing, incoming...), nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockSnapshotRepository interface {
+	LatestDate(onOrBefore time.Time) (*time.Time, error)
+	FindByDate(date time.Time, productID int) ([]domain.StockSnapshot, error)
+	Replace(date time.Time, snapshots []domain.StockSnapshot) error
+}
+
+type stockSnapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewStockSnapshotRepository(db *gorm.DB) StockSnapshotRepository {
+	return &stockSnapshotRepository{db: db}
+}
+
+// LatestDate mengembalikan tanggal snapshot terakhir yang tidak melewati onOrBefore, nil jika belum ada
+func (r *stockSnapshotRepository) LatestDate(onOrBefore time.Time) (*time.Time, error) {
+	var latest *time.Time
+	err := r.db.Model(&domain.StockSnapshot{}).
+		Select("MAX(snapshot_date)").
+		Where("snapshot_date <= ?", onOrBefore).
+		Scan(&latest).Error
+	return latest, err
+}
+
+// FindByDate mengambil snapshot pada tanggal date, productID 0 berarti semua produk
+func (r *stockSnapshotRepository) FindByDate(date time.Time, productID int) ([]domain.StockSnapshot, error) {
+	query := r.db.Where("snapshot_date = ?", date)
+	if productID != 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+
+	var snapshots []domain.StockSnapshot
+	err := query.Find(&snapshots).Error
+	return snapshots, err
+}
+
+// Replace mengganti seluruh snapshot pada tanggal date dalam satu transaksi
+func (r *stockSnapshotRepository) Replace(date time.Time, snapshots []domain.StockSnapshot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("snapshot_date = ?", date).Delete(&domain.StockSnapshot{}).Error; err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(snapshots, 500).Error
+	})
+}
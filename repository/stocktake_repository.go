@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StocktakeRepository interface {
+	FindAll() ([]domain.Stocktake, error)
+	FindById(id int) (domain.Stocktake, error)
+	FindByIdForUpdate(id int, tx *gorm.DB) (domain.Stocktake, error)
+	Save(stocktake domain.Stocktake) (domain.Stocktake, error)
+	UpdateStatus(stocktake domain.Stocktake, tx *gorm.DB) error
+	UpdateLine(line domain.StocktakeLine, tx *gorm.DB) error
+}
+
+type stocktakeRepository struct {
+	db *gorm.DB
+}
+
+func NewStocktakeRepository(db *gorm.DB) StocktakeRepository {
+	return &stocktakeRepository{db: db}
+}
+
+func (r *stocktakeRepository) FindAll() ([]domain.Stocktake, error) {
+	var stocktakes []domain.Stocktake
+	err := r.db.Preload("Lines.Product").Order("id desc").Find(&stocktakes).Error
+	return stocktakes, err
+}
+
+func (r *stocktakeRepository) FindById(id int) (domain.Stocktake, error) {
+	var stocktake domain.Stocktake
+	err := r.db.Preload("Lines.Product").First(&stocktake, id).Error
+	return stocktake, err
+}
+
+func (r *stocktakeRepository) FindByIdForUpdate(id int, tx *gorm.DB) (domain.Stocktake, error) {
+	var stocktake domain.Stocktake
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines").First(&stocktake, id).Error
+	return stocktake, err
+}
+
+func (r *stocktakeRepository) Save(stocktake domain.Stocktake) (domain.Stocktake, error) {
+	err := r.db.Create(&stocktake).Error
+	return stocktake, err
+}
+
+func (r *stocktakeRepository) UpdateStatus(stocktake domain.Stocktake, tx *gorm.DB) error {
+	return tx.Model(&domain.Stocktake{}).
+		Where("id = ?", stocktake.ID).
+		Updates(map[string]interface{}{
+			"status":      stocktake.Status,
+			"approved_by": stocktake.ApprovedBy,
+			"approved_at": stocktake.ApprovedAt,
+		}).Error
+}
+
+func (r *stocktakeRepository) UpdateLine(line domain.StocktakeLine, tx *gorm.DB) error {
+	return tx.Model(&domain.StocktakeLine{}).
+		Where("id = ?", line.ID).
+		Updates(map[string]interface{}{
+			"system_quantity":  line.SystemQuantity,
+			"counted_quantity": line.CountedQuantity,
+			"counted_by":       line.CountedBy,
+			"counted_at":       line.CountedAt,
+			"movement_id":      line.MovementID,
+		}).Error
+}
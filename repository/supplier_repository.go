@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SupplierRepository interface {
+	FindAll() ([]domain.Supplier, error)
+	FindById(id int) (domain.Supplier, error)
+	Save(supplier domain.Supplier) (domain.Supplier, error)
+	Update(supplier domain.Supplier) (domain.Supplier, error)
+	Delete(id int) error
+	IsInUse(id int) (bool, error)
+
+	FindProductSuppliers(productID int) ([]domain.ProductSupplier, error)
+	FindSupplierProducts(supplierID int) ([]domain.ProductSupplier, error)
+	FindPreferred() (map[int]domain.ProductSupplier, error)
+	SaveProductSupplier(link domain.ProductSupplier) (domain.ProductSupplier, error)
+	DeleteProductSupplier(productID, supplierID int) error
+}
+
+type supplierRepository struct {
+	db *gorm.DB
+}
+
+func NewSupplierRepository(db *gorm.DB) SupplierRepository {
+	return &supplierRepository{db: db}
+}
+
+func (r *supplierRepository) FindAll() ([]domain.Supplier, error) {
+	var suppliers []domain.Supplier
+	err := r.db.Order("id asc").Find(&suppliers).Error
+	return suppliers, err
+}
+
+func (r *supplierRepository) FindById(id int) (domain.Supplier, error) {
+	var supplier domain.Supplier
+	err := r.db.First(&supplier, id).Error
+	return supplier, err
+}
+
+func (r *supplierRepository) Save(supplier domain.Supplier) (domain.Supplier, error) {
+	err := r.db.Create(&supplier).Error
+	return supplier, err
+}
+
+func (r *supplierRepository) Update(supplier domain.Supplier) (domain.Supplier, error) {
+	err := r.db.Model(&domain.Supplier{}).
+		Where("id = ?", supplier.ID).
+		Updates(map[string]interface{}{
+			"name":           supplier.Name,
+			"contact_person": supplier.ContactPerson,
+			"email":          supplier.Email,
+			"phone":          supplier.Phone,
+			"address":        supplier.Address,
+			"lead_time_days": supplier.LeadTimeDays,
+			"payment_terms":  supplier.PaymentTerms,
+		}).Error
+	if err != nil {
+		return domain.Supplier{}, err
+	}
+	return r.FindById(supplier.ID)
+}
+
+func (r *supplierRepository) Delete(id int) error {
+	result := r.db.Delete(&domain.Supplier{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// IsInUse bernilai true jika supplier masih terhubung dengan produk
+func (r *supplierRepository) IsInUse(id int) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.ProductSupplier{}).Where("supplier_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *supplierRepository) FindProductSuppliers(productID int) ([]domain.ProductSupplier, error) {
+	var links []domain.ProductSupplier
+	err := r.db.Preload("Supplier").
+		Where("product_id = ?", productID).
+		Order("preferred desc, supplier_id asc").
+		Find(&links).Error
+	return links, err
+}
+
+func (r *supplierRepository) FindSupplierProducts(supplierID int) ([]domain.ProductSupplier, error) {
+	var links []domain.ProductSupplier
+	err := r.db.Preload("Product").Preload("Supplier").
+		Where("supplier_id = ?", supplierID).
+		Order("product_id asc").
+		Find(&links).Error
+	return links, err
+}
+
+// FindPreferred mengembalikan supplier preferred per product_id
+func (r *supplierRepository) FindPreferred() (map[int]domain.ProductSupplier, error) {
+	var links []domain.ProductSupplier
+	if err := r.db.Preload("Supplier").Where("preferred = ?", true).Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	result := map[int]domain.ProductSupplier{}
+	for _, l := range links {
+		result[l.ProductID] = l
+	}
+	return result, nil
+}
+
+// SaveProductSupplier membuat atau memperbarui hubungan produk-supplier. Jika link ditandai
+// preferred, tanda preferred pada supplier lain untuk produk yang sama dilepas.
+func (r *supplierRepository) SaveProductSupplier(link domain.ProductSupplier) (domain.ProductSupplier, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if link.Preferred {
+			err := tx.Model(&domain.ProductSupplier{}).
+				Where("product_id = ? AND supplier_id <> ?", link.ProductID, link.SupplierID).
+				Update("preferred", false).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit("Product", "Supplier").Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"supplier_sku", "last_purchase_price", "preferred", "updated_at"}),
+		}).Create(&link).Error
+	})
+	if err != nil {
+		return domain.ProductSupplier{}, err
+	}
+
+	var saved domain.ProductSupplier
+	err = r.db.Preload("Supplier").
+		Where("product_id = ? AND supplier_id = ?", link.ProductID, link.SupplierID).
+		First(&saved).Error
+	return saved, err
+}
+
+func (r *supplierRepository) DeleteProductSupplier(productID, supplierID int) error {
+	result := r.db.Where("product_id = ? AND supplier_id = ?", productID, supplierID).Delete(&domain.ProductSupplier{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type UnitRepository interface {
+	FindAll() ([]domain.Unit, error)
+	FindById(id int) (domain.Unit, error)
+	FindByCode(code string) (domain.Unit, error)
+	Save(unit domain.Unit) (domain.Unit, error)
+	Update(unit domain.Unit) (domain.Unit, error)
+	Delete(id int) error
+	IsInUse(id int) (bool, error)
+
+	FindProductUnits(productID int) ([]domain.ProductUnit, error)
+	ReplaceProductUnits(productID int, baseUnitID int, units []domain.ProductUnit) error
+	FactorsByUnit(unitID int) (map[int]int, error)
+}
+
+type unitRepository struct {
+	db *gorm.DB
+}
+
+func NewUnitRepository(db *gorm.DB) UnitRepository {
+	return &unitRepository{db: db}
+}
+
+func (r *unitRepository) FindAll() ([]domain.Unit, error) {
+	var units []domain.Unit
+	err := r.db.Order("code asc").Find(&units).Error
+	return units, err
+}
+
+func (r *unitRepository) FindById(id int) (domain.Unit, error) {
+	var unit domain.Unit
+	err := r.db.First(&unit, id).Error
+	return unit, err
+}
+
+func (r *unitRepository) FindByCode(code string) (domain.Unit, error) {
+	var unit domain.Unit
+	err := r.db.Where("code = ?", code).First(&unit).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Unit{}, errors.New("unit not found")
+	}
+	return unit, err
+}
+
+func (r *unitRepository) Save(unit domain.Unit) (domain.Unit, error) {
+	err := r.db.Create(&unit).Error
+	return unit, err
+}
+
+func (r *unitRepository) Update(unit domain.Unit) (domain.Unit, error) {
+	err := r.db.Model(&domain.Unit{}).
+		Where("id = ?", unit.ID).
+		Updates(map[string]interface{}{
+			"code": unit.Code,
+			"name": unit.Name,
+		}).Error
+	if err != nil {
+		return domain.Unit{}, err
+	}
+	return r.FindById(unit.ID)
+}
+
+func (r *unitRepository) Delete(id int) error {
+	result := r.db.Delete(&domain.Unit{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// IsInUse bernilai true jika satuan dipakai sebagai satuan dasar, satuan alternatif, atau pada movement
+func (r *unitRepository) IsInUse(id int) (bool, error) {
+	checks := []interface{}{&domain.Product{}, &domain.ProductUnit{}, &domain.StockMovement{}}
+	columns := []string{"base_unit_id", "unit_id", "unit_id"}
+
+	for i, model := range checks {
+		var count int64
+		if err := r.db.Model(model).Where(columns[i]+" = ?", id).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *unitRepository) FindProductUnits(productID int) ([]domain.ProductUnit, error) {
+	var units []domain.ProductUnit
+	err := r.db.Preload("Unit").
+		Where("product_id = ?", productID).
+		Order("factor asc").
+		Find(&units).Error
+	return units, err
+}
+
+// ReplaceProductUnits mengganti satuan dasar dan seluruh satuan alternatif produk dalam satu transaksi
+func (r *unitRepository) ReplaceProductUnits(productID int, baseUnitID int, units []domain.ProductUnit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Product{}).Where("id = ?", productID).Update("base_unit_id", baseUnitID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", productID).Delete(&domain.ProductUnit{}).Error; err != nil {
+			return err
+		}
+		if len(units) == 0 {
+			return nil
+		}
+		return tx.Omit("Unit").Create(&units).Error
+	})
+}
+
+// FactorsByUnit mengembalikan faktor konversi satuan unitID per product_id, termasuk
+// produk yang satuan dasarnya unitID (faktor 1)
+func (r *unitRepository) FactorsByUnit(unitID int) (map[int]int, error) {
+	var units []domain.ProductUnit
+	if err := r.db.Where("unit_id = ?", unitID).Find(&units).Error; err != nil {
+		return nil, err
+	}
+	var baseProductIDs []int
+	if err := r.db.Model(&domain.Product{}).Where("base_unit_id = ?", unitID).Pluck("id", &baseProductIDs).Error; err != nil {
+		return nil, err
+	}
+
+	factors := map[int]int{}
+	for _, u := range units {
+		factors[u.ProductID] = u.Factor
+	}
+	for _, id := range baseProductIDs {
+		factors[id] = 1
+	}
+	return factors, nil
+}
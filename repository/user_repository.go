@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"inventory-management-api/model/domain"
 
 	"gorm.io/gorm"
@@ -13,6 +14,12 @@ type UserRepository interface {
 	Save(user *domain.User) (*domain.User, error)
 	Update(user *domain.User) (*domain.User, error)
 	Delete(user *domain.User) error
+
+	FindTrashed() ([]domain.User, error)
+	FindTrashedByID(id int) (*domain.User, error)
+	Restore(id int) error
+	CountReferences(id int) (map[string]int, error)
+	Purge(id int) error
 }
 
 type userRepositoryImpl struct {
@@ -54,3 +61,52 @@ func (r *userRepositoryImpl) Update(user *domain.User) (*domain.User, error) {
 func (r *userRepositoryImpl) Delete(user *domain.User) error {
 	return r.DB.Delete(user).Error
 }
+
+// FindTrashed mengambil user yang sudah dihapus (soft delete), terbaru lebih dulu
+func (r *userRepositoryImpl) FindTrashed() ([]domain.User, error) {
+	var users []domain.User
+	err := r.DB.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&users).Error
+	return users, err
+}
+
+func (r *userRepositoryImpl) FindTrashedByID(id int) (*domain.User, error) {
+	var user domain.User
+	err := r.DB.Unscoped().Where("deleted_at IS NOT NULL").First(&user, id).Error
+	return &user, err
+}
+
+func (r *userRepositoryImpl) Restore(id int) error {
+	return r.DB.Unscoped().Model(&domain.User{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// CountReferences menghitung transaksi dan dokumen yang dicatat atas nama user id
+func (r *userRepositoryImpl) CountReferences(id int) (map[string]int, error) {
+	return countReferences(r.DB, id, []reference{
+		{Name: "stock_movements", Model: &domain.StockMovement{}, Where: "user_id = ?"},
+		{Name: "stock_documents", Model: &domain.StockDocument{}, Where: "user_id = ?"},
+		{Name: "reservations", Model: &domain.Reservation{}, Where: "created_by = ?"},
+		{Name: "purchase_orders", Model: &domain.PurchaseOrder{}, Where: "created_by = ?"},
+		{Name: "sales_orders", Model: &domain.SalesOrder{}, Where: "created_by = ?"},
+		{Name: "stocktakes", Model: &domain.Stocktake{}, Where: "created_by = ?"},
+		{Name: "approved_stocktakes", Model: &domain.Stocktake{}, Where: "approved_by = ?"},
+		{Name: "category_deletions", Model: &domain.CategoryDeletion{}, Where: "user_id = ?"},
+	})
+}
+
+// Purge menghapus permanen user beserta idempotency key miliknya. Pastikan CountReferences
+// kosong sebelum memanggilnya.
+func (r *userRepositoryImpl) Purge(id int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&domain.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Delete(&domain.User{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("user not found")
+		}
+		return nil
+	})
+}
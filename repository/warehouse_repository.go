@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"inventory-management-api/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type WarehouseRepository interface {
+	FindAll() ([]domain.Warehouse, error)
+	FindById(id int) (domain.Warehouse, error)
+	Save(warehouse domain.Warehouse) (domain.Warehouse, error)
+	Update(warehouse domain.Warehouse) (domain.Warehouse, error)
+	Delete(id int) error
+	IsInUse(id int) (bool, error)
+}
+
+type warehouseRepository struct {
+	db *gorm.DB
+}
+
+func NewWarehouseRepository(db *gorm.DB) WarehouseRepository {
+	return &warehouseRepository{db: db}
+}
+
+func (r *warehouseRepository) FindAll() ([]domain.Warehouse, error) {
+	var warehouses []domain.Warehouse
+	err := r.db.Order("id asc").Find(&warehouses).Error
+	return warehouses, err
+}
+
+func (r *warehouseRepository) FindById(id int) (domain.Warehouse, error) {
+	var warehouse domain.Warehouse
+	err := r.db.First(&warehouse, id).Error
+	return warehouse, err
+}
+
+func (r *warehouseRepository) Save(warehouse domain.Warehouse) (domain.Warehouse, error) {
+	err := r.db.Create(&warehouse).Error
+	return warehouse, err
+}
+
+func (r *warehouseRepository) Update(warehouse domain.Warehouse) (domain.Warehouse, error) {
+	err := r.db.Model(&domain.Warehouse{}).
+		Where("id = ?", warehouse.ID).
+		Updates(map[string]interface{}{
+			"name":    warehouse.Name,
+			"address": warehouse.Address,
+		}).Error
+
+	if err != nil {
+		return domain.Warehouse{}, err
+	}
+
+	var updatedWarehouse domain.Warehouse
+	err = r.db.First(&updatedWarehouse, warehouse.ID).Error
+	return updatedWarehouse, err
+}
+
+// Delete menghapus gudang beserta baris saldo stoknya yang bernilai 0 (misalnya dibuat oleh
+// migrasi gudang default) dalam satu transaksi. Gudang harus sudah dicek dengan IsInUse.
+func (r *warehouseRepository) Delete(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("warehouse_id = ? AND stock = 0", id).Delete(&domain.ProductStock{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Warehouse{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// IsInUse bernilai true jika gudang masih punya saldo stok, pernah dipakai di movement, atau
+// masih dirujuk order, reservasi, maupun stock opname
+func (r *warehouseRepository) IsInUse(id int) (bool, error) {
+	queries := []*gorm.DB{
+		r.db.Model(&domain.ProductStock{}).Where("warehouse_id = ? AND stock <> 0", id),
+		r.db.Model(&domain.StockMovement{}).Where("warehouse_id = ? OR to_warehouse_id = ?", id, id),
+		r.db.Model(&domain.PurchaseOrder{}).Where("warehouse_id = ?", id),
+		r.db.Model(&domain.SalesOrder{}).Where("warehouse_id = ?", id),
+		r.db.Model(&domain.Reservation{}).Where("warehouse_id = ?", id),
+		r.db.Model(&domain.Stocktake{}).Where("warehouse_id = ?", id),
+	}
+	for _, query := range queries {
+		var count int64
+		if err := query.Count(&count).Error; err != nil || count > 0 {
+			return count > 0, err
+		}
+	}
+	return false, nil
+}
@@ -7,15 +7,21 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func RegisterCategoryRoutes(app *fiber.App, controller *controller.CategoryController) {
+func RegisterCategoryRoutes(app *fiber.App, controller *controller.CategoryController, idempotent fiber.Handler) {
 	category := app.Group("/categories", middleware.JWTMiddleware)
 
 	// Bisa diakses oleh admin dan staff
 	category.Get("/", controller.FindAll)
+	category.Get("/tree", controller.FindTree)
+	category.Get("/trash", middleware.AdminOnly, controller.FindTrashed)
 	category.Get("/:id", controller.FindById)
 
 	// Hanya admin yang boleh manipulasi data kategori
-	category.Post("/", middleware.AdminOnly, controller.Create)
+	category.Post("/", middleware.AdminOnly, idempotent, controller.Create)
 	category.Put("/:id", middleware.AdminOnly, controller.Update)
 	category.Delete("/:id", middleware.AdminOnly, controller.Delete)
+
+	// Trash: kategori yang sudah dihapus bisa dipulihkan atau dihapus permanen
+	category.Post("/trash/:id/restore", middleware.AdminOnly, controller.Restore)
+	category.Delete("/trash/:id", middleware.AdminOnly, controller.Purge)
 }
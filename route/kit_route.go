@@ -0,0 +1,20 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Kit didaftarkan langsung agar middleware grup /products tidak terpasang dua kali
+func RegisterKitRoutes(app *fiber.App, controller *controller.KitController, idempotent fiber.Handler) {
+	// Bisa diakses oleh admin dan staff
+	app.Get("/products/:id/components", middleware.JWTMiddleware, controller.FindKit)
+
+	// Hanya admin yang boleh mengatur bill of materials
+	app.Put("/products/:id/components", middleware.JWTMiddleware, middleware.AdminOnly, controller.SetComponents)
+
+	// Perakitan adalah transaksi stok, hanya staff seperti stock movement
+	app.Post("/products/:id/assemble", middleware.JWTMiddleware, middleware.StaffOnly, idempotent, controller.Assemble)
+}
@@ -0,0 +1,18 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterProductAttachmentRoutes(app *fiber.App, controller *controller.ProductAttachmentController, idempotent fiber.Handler) {
+	// Didaftarkan langsung agar middleware grup /products tidak terpasang dua kali
+	app.Get("/products/:id/attachments", middleware.JWTMiddleware, controller.FindByProduct)
+	app.Get("/products/:id/attachments/:attachment_id/file", middleware.JWTMiddleware, controller.Download)
+	app.Get("/products/:id/attachments/:attachment_id/thumbnail", middleware.JWTMiddleware, controller.Thumbnail)
+	app.Post("/products/:id/images", middleware.JWTMiddleware, middleware.AdminOnly, idempotent, controller.UploadImage)
+	app.Post("/products/:id/documents", middleware.JWTMiddleware, middleware.AdminOnly, idempotent, controller.UploadDocument)
+	app.Delete("/products/:id/attachments/:attachment_id", middleware.JWTMiddleware, middleware.AdminOnly, controller.Delete)
+}
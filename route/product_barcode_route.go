@@ -0,0 +1,15 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterProductBarcodeRoutes(app *fiber.App, controller *controller.ProductBarcodeController, idempotent fiber.Handler) {
+	// Didaftarkan langsung agar middleware grup /products tidak terpasang dua kali
+	app.Get("/products/:id/barcodes", middleware.JWTMiddleware, controller.FindByProduct)
+	app.Post("/products/:id/barcodes", middleware.JWTMiddleware, middleware.AdminOnly, idempotent, controller.Create)
+	app.Delete("/products/:id/barcodes/:barcode_id", middleware.JWTMiddleware, middleware.AdminOnly, controller.Delete)
+}
@@ -7,16 +7,25 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func RegisterProductRoutes(app *fiber.App, controller *controller.ProductController) {
+func RegisterProductRoutes(app *fiber.App, controller *controller.ProductController, idempotent fiber.Handler) {
 	product := app.Group("/products", middleware.JWTMiddleware)
 
 	// Boleh diakses oleh staff dan admin
 	product.Get("/search", controller.Search)
+	product.Get("/low-stock", controller.LowStock)
+	product.Get("/lookup", controller.Lookup)
+	product.Get("/trash", middleware.AdminOnly, controller.FindTrashed)
 	product.Get("/", controller.FindAll)
 	product.Get("/:id", controller.FindById)
+	product.Get("/:id/variants", controller.FindVariants)
 
 	// Hanya admin yang boleh manipulasi data
-	product.Post("/", middleware.AdminOnly, controller.Create)
+	product.Post("/", middleware.AdminOnly, idempotent, controller.Create)
+	product.Post("/:id/variants", middleware.AdminOnly, idempotent, controller.CreateVariant)
 	product.Put("/:id", middleware.AdminOnly, controller.Update)
 	product.Delete("/:id", middleware.AdminOnly, controller.Delete)
+
+	// Trash: produk yang sudah dihapus bisa dipulihkan atau dihapus permanen
+	product.Post("/trash/:id/restore", middleware.AdminOnly, controller.Restore)
+	product.Delete("/trash/:id", middleware.AdminOnly, controller.Purge)
 }
@@ -0,0 +1,25 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterPurchaseOrderRoutes(app *fiber.App, c *controller.PurchaseOrderController, idempotent fiber.Handler) {
+	order := app.Group("/purchase-orders", middleware.JWTMiddleware)
+
+	// Dapat diakses oleh admin dan staff
+	order.Get("/", c.FindAll)
+	order.Get("/:id", c.FindById)
+
+	// Penerimaan barang dilakukan staff gudang (admin boleh mengizinkan over receipt)
+	order.Post("/:id/receive", idempotent, c.Receive)
+
+	// Hanya admin yang membuat, mengubah, memesan, dan membatalkan PO
+	order.Post("/", middleware.AdminOnly, idempotent, c.Create)
+	order.Put("/:id", middleware.AdminOnly, c.Update)
+	order.Post("/:id/order", middleware.AdminOnly, idempotent, c.Order)
+	order.Post("/:id/cancel", middleware.AdminOnly, idempotent, c.Cancel)
+}
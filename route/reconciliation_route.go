@@ -0,0 +1,16 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterReconciliationRoutes(app *fiber.App, c *controller.ReconciliationController, idempotent fiber.Handler) {
+	// Hanya admin yang boleh menjalankan rekonsiliasi
+	reconciliation := app.Group("/admin/reconciliation", middleware.JWTMiddleware, middleware.AdminOnly)
+
+	reconciliation.Get("/", c.Check)
+	reconciliation.Post("/repair", idempotent, c.Repair)
+}
@@ -0,0 +1,13 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterReplenishmentRoutes(app *fiber.App, c *controller.ReplenishmentController) {
+	// Laporan untuk bagian pembelian - hanya admin yang boleh akses
+	app.Get("/reports/replenishment", middleware.JWTMiddleware, middleware.AdminOnly, c.Suggest)
+}
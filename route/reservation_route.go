@@ -0,0 +1,18 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterReservationRoutes(app *fiber.App, c *controller.ReservationController, idempotent fiber.Handler) {
+	reservation := app.Group("/reservations", middleware.JWTMiddleware)
+
+	// Dapat diakses oleh admin dan staff
+	reservation.Get("/", c.FindAll)
+	reservation.Get("/:id", c.FindById)
+	reservation.Post("/", idempotent, c.Create)
+	reservation.Post("/:id/release", idempotent, c.Release)
+}
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterSalesOrderRoutes(app *fiber.App, c *controller.SalesOrderController, idempotent fiber.Handler) {
+	order := app.Group("/sales-orders", middleware.JWTMiddleware)
+
+	// Dapat diakses oleh admin dan staff
+	order.Get("/", c.FindAll)
+	order.Get("/:id", c.FindById)
+	order.Post("/", idempotent, c.Create)
+	order.Put("/:id", c.Update)
+	order.Post("/:id/confirm", idempotent, c.Confirm)
+	order.Post("/:id/cancel", idempotent, c.Cancel)
+
+	// Pengambilan dan pengiriman barang dilakukan staff gudang, sama seperti stok keluar
+	order.Post("/:id/pick", middleware.StaffOnly, idempotent, c.Pick)
+	order.Post("/:id/ship", middleware.StaffOnly, idempotent, c.Ship)
+}
@@ -0,0 +1,15 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterSerialNumberRoutes(app *fiber.App, c *controller.SerialNumberController) {
+	// Dapat diakses oleh admin dan staff
+	serial := app.Group("/serials", middleware.JWTMiddleware)
+
+	serial.Get("/:serial", c.FindBySerial)
+}
@@ -0,0 +1,15 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterStockAlertRoutes(app *fiber.App, c *controller.StockAlertController) {
+	alert := app.Group("/stock-alerts", middleware.JWTMiddleware)
+
+	// Dapat diakses oleh admin dan staff
+	alert.Get("/", c.FindAll)
+}
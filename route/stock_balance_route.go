@@ -0,0 +1,16 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterStockBalanceRoutes(app *fiber.App, c *controller.StockBalanceController) {
+	// Saldo historis satu produk - admin dan staff
+	app.Get("/products/:id/stock", middleware.JWTMiddleware, c.ProductBalance)
+
+	// Laporan saldo seluruh produk - hanya admin yang boleh akses
+	app.Get("/reports/stock-balance", middleware.JWTMiddleware, middleware.AdminOnly, c.Report)
+}
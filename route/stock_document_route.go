@@ -0,0 +1,19 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterStockDocumentRoutes(app *fiber.App, c *controller.StockDocumentController, idempotent fiber.Handler) {
+	document := app.Group("/stock-documents", middleware.JWTMiddleware)
+
+	// Dapat diakses oleh admin dan staff
+	document.Get("/", c.FindAll)
+	document.Get("/:id", c.FindById)
+
+	// Hanya staff yang memposting dokumen, sama seperti movement satuan
+	document.Post("/", middleware.StaffOnly, idempotent, c.Create)
+}
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterStockLotRoutes(app *fiber.App, c *controller.StockLotController) {
+	// Dapat diakses oleh admin dan staff
+	lot := app.Group("/lots", middleware.JWTMiddleware)
+
+	lot.Get("/expiring", c.FindExpiring)
+	lot.Get("/", c.FindAll)
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func RegisterStockMovementRoutes(app *fiber.App, c *controller.StockMovementController) {
+func RegisterStockMovementRoutes(app *fiber.App, c *controller.StockMovementController, idempotent fiber.Handler) {
 	// Group untuk stock movements
 	stock := app.Group("/stock-movements", middleware.JWTMiddleware)
 
@@ -16,10 +16,11 @@ func RegisterStockMovementRoutes(app *fiber.App, c *controller.StockMovementCont
 	stock.Get("/:id", c.FindById)
 
 	// Hanya Staff yang boleh buat transaksi
-	stock.Post("/", middleware.StaffOnly, c.Create)
+	stock.Post("/", middleware.StaffOnly, idempotent, c.Create)
 
-	// Hanya Admin yang boleh hapus transaksi
-	stock.Delete("/:id", middleware.AdminOnly, c.Delete)
+	// Hanya Admin yang boleh membalik transaksi (DELETE dipertahankan sebagai alias)
+	stock.Post("/:id/reverse", middleware.AdminOnly, idempotent, c.Reverse)
+	stock.Delete("/:id", middleware.AdminOnly, idempotent, c.Reverse)
 
 	// ✅ Endpoint laporan bulanan - hanya admin yang boleh akses
 	app.Get("/reports/stock-movements", middleware.JWTMiddleware, middleware.AdminOnly, c.GetMonthlyReport)
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterStocktakeRoutes(app *fiber.App, c *controller.StocktakeController, idempotent fiber.Handler) {
+	stocktake := app.Group("/stocktakes", middleware.JWTMiddleware)
+
+	// Dapat diakses oleh admin dan staff
+	stocktake.Get("/", c.FindAll)
+	stocktake.Get("/:id", c.FindById)
+
+	// Staff yang menghitung fisik dan mengirim hasilnya
+	stocktake.Put("/:id/counts", middleware.StaffOnly, c.SubmitCounts)
+
+	// Admin membuka, menyetujui, dan membatalkan sesi
+	stocktake.Post("/", middleware.AdminOnly, idempotent, c.Create)
+	stocktake.Post("/:id/approve", middleware.AdminOnly, idempotent, c.Approve)
+	stocktake.Post("/:id/cancel", middleware.AdminOnly, idempotent, c.Cancel)
+}
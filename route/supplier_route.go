@@ -0,0 +1,27 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterSupplierRoutes(app *fiber.App, controller *controller.SupplierController, idempotent fiber.Handler) {
+	supplier := app.Group("/suppliers", middleware.JWTMiddleware)
+
+	// Bisa diakses oleh admin dan staff
+	supplier.Get("/", controller.FindAll)
+	supplier.Get("/:id", controller.FindById)
+	supplier.Get("/:id/products", controller.FindProducts)
+
+	// Hanya admin yang boleh manipulasi data supplier
+	supplier.Post("/", middleware.AdminOnly, idempotent, controller.Create)
+	supplier.Put("/:id", middleware.AdminOnly, controller.Update)
+	supplier.Delete("/:id", middleware.AdminOnly, controller.Delete)
+
+	// Hubungan produk-supplier didaftarkan langsung agar middleware grup /products tidak terpasang dua kali
+	app.Get("/products/:id/suppliers", middleware.JWTMiddleware, controller.FindByProduct)
+	app.Put("/products/:id/suppliers/:supplier_id", middleware.JWTMiddleware, middleware.AdminOnly, controller.LinkProduct)
+	app.Delete("/products/:id/suppliers/:supplier_id", middleware.JWTMiddleware, middleware.AdminOnly, controller.UnlinkProduct)
+}
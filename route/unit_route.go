@@ -0,0 +1,24 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterUnitRoutes(app *fiber.App, controller *controller.UnitController, idempotent fiber.Handler) {
+	unit := app.Group("/units", middleware.JWTMiddleware)
+
+	// Bisa diakses oleh admin dan staff
+	unit.Get("/", controller.FindAll)
+
+	// Hanya admin yang boleh manipulasi katalog satuan
+	unit.Post("/", middleware.AdminOnly, idempotent, controller.Create)
+	unit.Put("/:id", middleware.AdminOnly, controller.Update)
+	unit.Delete("/:id", middleware.AdminOnly, controller.Delete)
+
+	// Satuan produk didaftarkan langsung agar middleware grup /products tidak terpasang dua kali
+	app.Get("/products/:id/units", middleware.JWTMiddleware, controller.FindProductUnits)
+	app.Put("/products/:id/units", middleware.JWTMiddleware, middleware.AdminOnly, controller.SetProductUnits)
+}
@@ -7,12 +7,17 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func RegisterUserRoutes(app *fiber.App, controller *controller.UserController) {
+func RegisterUserRoutes(app *fiber.App, controller *controller.UserController, idempotent fiber.Handler) {
 	userGroup := app.Group("/users", middleware.JWTMiddleware, middleware.AdminOnly)
 
 	userGroup.Get("/", controller.FindAll)
+	userGroup.Get("/trash", controller.FindTrashed)
 	userGroup.Get("/:id", controller.FindByID)
-	userGroup.Post("/", controller.Create)
+	userGroup.Post("/", idempotent, controller.Create)
 	userGroup.Put("/:id", controller.Update)
 	userGroup.Delete("/:id", controller.Delete)
+
+	// Trash: user yang sudah dihapus bisa dipulihkan atau dihapus permanen
+	userGroup.Post("/trash/:id/restore", controller.Restore)
+	userGroup.Delete("/trash/:id", controller.Purge)
 }
@@ -0,0 +1,13 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterValuationRoutes(app *fiber.App, c *controller.ValuationController) {
+	// Laporan untuk bagian keuangan - hanya admin yang boleh akses
+	app.Get("/reports/valuation", middleware.JWTMiddleware, middleware.AdminOnly, c.Valuation)
+}
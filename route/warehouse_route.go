@@ -0,0 +1,21 @@
+package route
+
+import (
+	"inventory-management-api/controller"
+	"inventory-management-api/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterWarehouseRoutes(app *fiber.App, controller *controller.WarehouseController, idempotent fiber.Handler) {
+	warehouse := app.Group("/warehouses", middleware.JWTMiddleware)
+
+	// Bisa diakses oleh admin dan staff
+	warehouse.Get("/", controller.FindAll)
+	warehouse.Get("/:id", controller.FindById)
+
+	// Hanya admin yang boleh manipulasi data gudang
+	warehouse.Post("/", middleware.AdminOnly, idempotent, controller.Create)
+	warehouse.Put("/:id", middleware.AdminOnly, controller.Update)
+	warehouse.Delete("/:id", middleware.AdminOnly, controller.Delete)
+}
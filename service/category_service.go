@@ -1,10 +1,12 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"inventory-management-api/model/domain"
 	"inventory-management-api/model/web"
 	"inventory-management-api/repository"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -12,20 +14,28 @@ import (
 type CategoryService interface {
 	FindAll() ([]web.CategoryResponse, error)
 	FindById(id int) (web.CategoryResponse, error)
+	FindTree() ([]web.CategoryTreeResponse, error)
 	Create(request web.CategoryCreateOrUpdateRequest) (web.CategoryResponse, error)
 	Update(id int, request web.CategoryCreateOrUpdateRequest) (web.CategoryResponse, error)
-	Delete(id int) error
+	Delete(id int, userID int, request web.CategoryDeleteRequest) (web.CategoryDeleteResponse, error)
+	FindTrashed() ([]web.CategoryResponse, error)
+	Restore(id int) (web.CategoryResponse, error)
+	Purge(id int) (web.PurgeResponse, error)
 }
 
 type categoryService struct {
-	Repository repository.CategoryRepository
-	Validate   *validator.Validate
+	Repository  repository.CategoryRepository
+	RepoProduct repository.ProductRepository
+	RepoKit     repository.KitRepository
+	Validate    *validator.Validate
 }
 
-func NewCategoryService(repo repository.CategoryRepository, validate *validator.Validate) CategoryService {
+func NewCategoryService(repo repository.CategoryRepository, repoProduct repository.ProductRepository, repoKit repository.KitRepository, validate *validator.Validate) CategoryService {
 	return &categoryService{
-		Repository: repo,
-		Validate:   validate,
+		Repository:  repo,
+		RepoProduct: repoProduct,
+		RepoKit:     repoKit,
+		Validate:    validate,
 	}
 }
 
@@ -37,10 +47,7 @@ func (s *categoryService) FindAll() ([]web.CategoryResponse, error) {
 
 	var responses []web.CategoryResponse
 	for _, c := range categories {
-		responses = append(responses, web.CategoryResponse{
-			ID:   c.ID,
-			Name: c.Name,
-		})
+		responses = append(responses, toCategoryResponse(c, categories))
 	}
 	return responses, nil
 }
@@ -50,22 +57,61 @@ func (s *categoryService) FindById(id int) (web.CategoryResponse, error) {
 	if err != nil {
 		return web.CategoryResponse{}, err
 	}
-	return web.CategoryResponse{ID: c.ID, Name: c.Name}, nil
+	return s.withPath(c)
+}
+
+// FindTree mengembalikan seluruh kategori sebagai pohon, mulai dari kategori teratas
+func (s *categoryService) FindTree() ([]web.CategoryTreeResponse, error) {
+	categories, err := s.Repository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[int][]domain.Category{}
+	var roots []domain.Category
+	for _, c := range categories {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+		} else {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		}
+	}
+
+	var build func(c domain.Category) web.CategoryTreeResponse
+	build = func(c domain.Category) web.CategoryTreeResponse {
+		node := web.CategoryTreeResponse{ID: c.ID, Name: c.Name, Children: []web.CategoryTreeResponse{}}
+		for _, child := range children[c.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	tree := []web.CategoryTreeResponse{}
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree, nil
 }
 
 func (s *categoryService) Create(req web.CategoryCreateOrUpdateRequest) (web.CategoryResponse, error) {
 	if err := s.Validate.Struct(req); err != nil {
 		return web.CategoryResponse{}, fmt.Errorf("validation error: %w", err)
 	}
+	if req.ParentID != nil {
+		if _, err := s.Repository.FindById(*req.ParentID); err != nil {
+			return web.CategoryResponse{}, errors.New("parent category not found")
+		}
+	}
 
 	category := domain.Category{
-		Name: req.Name,
+		Name:     req.Name,
+		ParentID: req.ParentID,
 	}
 	saved, err := s.Repository.Save(category)
 	if err != nil {
 		return web.CategoryResponse{}, err
 	}
-	return web.CategoryResponse{ID: saved.ID, Name: saved.Name}, nil
+	return s.withPath(saved)
 }
 
 func (s *categoryService) Update(id int, req web.CategoryCreateOrUpdateRequest) (web.CategoryResponse, error) {
@@ -80,8 +126,25 @@ func (s *categoryService) Update(id int, req web.CategoryCreateOrUpdateRequest)
 		return web.CategoryResponse{}, err
 	}
 
+	// Kategori tidak boleh dipindah ke bawah dirinya sendiri atau subkategorinya
+	if req.ParentID != nil {
+		if _, err := s.Repository.FindById(*req.ParentID); err != nil {
+			return web.CategoryResponse{}, errors.New("parent category not found")
+		}
+		subtree, err := s.Repository.FindSubtreeIds(id)
+		if err != nil {
+			return web.CategoryResponse{}, err
+		}
+		for _, descendant := range subtree {
+			if descendant == *req.ParentID {
+				return web.CategoryResponse{}, errors.New("category cannot be moved under itself or its subcategory")
+			}
+		}
+	}
+
 	// Ubah hanya field yang diizinkan
 	existingCategory.Name = req.Name
+	existingCategory.ParentID = req.ParentID
 
 	// Simpan kembali ke database
 	updated, err := s.Repository.Update(existingCategory)
@@ -90,9 +153,197 @@ func (s *categoryService) Update(id int, req web.CategoryCreateOrUpdateRequest)
 	}
 
 	// Return response
-	return web.CategoryResponse{ID: updated.ID, Name: updated.Name}, nil
+	return s.withPath(updated)
+}
+
+// Delete menghapus kategori sesuai mode pada request. Response selalu berisi jumlah produk
+// dan subkategori yang terdampak, termasuk saat penghapusan ditolak.
+func (s *categoryService) Delete(id int, userID int, req web.CategoryDeleteRequest) (web.CategoryDeleteResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.CategoryDeleteResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	category, err := s.Repository.FindById(id)
+	if err != nil {
+		return web.CategoryDeleteResponse{}, errors.New("category not found")
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = "refuse"
+	}
+	productCount, err := s.Repository.CountProducts(id)
+	if err != nil {
+		return web.CategoryDeleteResponse{}, err
+	}
+	subcategoryCount, err := s.Repository.CountChildren(id)
+	if err != nil {
+		return web.CategoryDeleteResponse{}, err
+	}
+	response := web.CategoryDeleteResponse{
+		CategoryID:       id,
+		Mode:             mode,
+		ProductCount:     productCount,
+		SubcategoryCount: subcategoryCount,
+	}
+
+	switch mode {
+	case "refuse":
+		if productCount > 0 || subcategoryCount > 0 {
+			return response, errors.New("category is still in use")
+		}
+	case "reassign":
+		// Kategori tujuan tidak boleh kategori ini sendiri atau subkategorinya
+		if _, err := s.Repository.FindById(req.TargetID); err != nil {
+			return response, errors.New("target category not found")
+		}
+		subtree, err := s.Repository.FindSubtreeIds(id)
+		if err != nil {
+			return web.CategoryDeleteResponse{}, err
+		}
+		for _, descendant := range subtree {
+			if descendant == req.TargetID {
+				return response, errors.New("target category cannot be the deleted category or its subcategory")
+			}
+		}
+		response.TargetCategoryID = &req.TargetID
+	case "cascade":
+		// Subkategori tidak ikut dihapus, dan setiap produk harus lolos pengecekan yang sama
+		// dengan penghapusan satu produk
+		if subcategoryCount > 0 {
+			return response, errors.New("category has subcategories")
+		}
+		products, err := s.RepoProduct.FindByCategoryId(id)
+		if err != nil {
+			return web.CategoryDeleteResponse{}, err
+		}
+		deleting := map[int]bool{}
+		for _, p := range products {
+			deleting[p.ID] = true
+		}
+		for _, p := range products {
+			if err := checkProductDeletable(s.RepoProduct, s.RepoKit, p.ID, deleting); err != nil {
+				return response, err
+			}
+		}
+	}
+
+	err = s.Repository.DeleteWithPolicy(domain.CategoryDeletion{
+		CategoryID:       id,
+		CategoryName:     category.Name,
+		Mode:             mode,
+		TargetCategoryID: response.TargetCategoryID,
+		ProductCount:     productCount,
+		SubcategoryCount: subcategoryCount,
+		UserID:           userID,
+	})
+	if err != nil {
+		return web.CategoryDeleteResponse{}, err
+	}
+	return response, nil
+}
+
+// FindTrashed mengambil kategori yang sudah dihapus dan masih bisa dipulihkan. Breadcrumb
+// disusun dari kategori aktif dan kategori di trash.
+func (s *categoryService) FindTrashed() ([]web.CategoryResponse, error) {
+	trashed, err := s.Repository.FindTrashed()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.Repository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	categories = append(categories, trashed...)
+
+	var responses []web.CategoryResponse
+	for _, c := range trashed {
+		responses = append(responses, toCategoryResponse(c, categories))
+	}
+	return responses, nil
+}
+
+// Restore memulihkan kategori dari trash. Kategori induknya harus dipulihkan lebih dulu.
+// Produk yang ikut terhapus (mode cascade) dipulihkan terpisah lewat trash produk.
+func (s *categoryService) Restore(id int) (web.CategoryResponse, error) {
+	category, err := s.Repository.FindTrashedById(id)
+	if err != nil {
+		return web.CategoryResponse{}, errors.New("category not found")
+	}
+	if category.ParentID != nil {
+		if _, err := s.Repository.FindById(*category.ParentID); err != nil {
+			return web.CategoryResponse{}, errors.New("parent category is deleted")
+		}
+	}
+
+	if err := s.Repository.Restore(id); err != nil {
+		return web.CategoryResponse{}, err
+	}
+	return s.FindById(id)
+}
+
+// Purge menghapus permanen kategori yang sudah ada di trash. Ditolak selama masih ada produk
+// (termasuk di trash), subkategori, atau stocktake yang merujuknya.
+func (s *categoryService) Purge(id int) (web.PurgeResponse, error) {
+	if _, err := s.Repository.FindTrashedById(id); err != nil {
+		return web.PurgeResponse{}, errors.New("category not found")
+	}
+	references, err := s.Repository.CountReferences(id)
+	if err != nil {
+		return web.PurgeResponse{}, err
+	}
+	response := web.PurgeResponse{ID: id, References: references}
+	if len(references) > 0 {
+		return response, errors.New("category is still referenced")
+	}
+	return response, s.Repository.Purge(id)
+}
+
+// withPath membuat response kategori c lengkap dengan breadcrumb-nya
+func (s *categoryService) withPath(c domain.Category) (web.CategoryResponse, error) {
+	categories, err := s.Repository.FindAll()
+	if err != nil {
+		return web.CategoryResponse{}, err
+	}
+	return toCategoryResponse(c, categories), nil
 }
 
-func (s *categoryService) Delete(id int) error {
-	return s.Repository.Delete(id)
+func toCategoryResponse(c domain.Category, categories []domain.Category) web.CategoryResponse {
+	path := categoryPath(c, categories)
+
+	names := make([]string, 0, len(path))
+	for _, p := range path {
+		names = append(names, p.Name)
+	}
+	response := web.CategoryResponse{
+		ID:       c.ID,
+		Name:     c.Name,
+		ParentID: c.ParentID,
+		Path:     path,
+		FullName: strings.Join(names, " > "),
+	}
+	if c.DeletedAt.Valid {
+		response.DeletedAt = &c.DeletedAt.Time
+	}
+	return response
+}
+
+// categoryPath menyusun breadcrumb kategori c dari kategori teratas. Penelusuran dibatasi
+// jumlah kategori agar data yang terlanjur berputar tidak membuat loop tanpa akhir.
+func categoryPath(c domain.Category, categories []domain.Category) []web.CategoryPathResponse {
+	byID := map[int]domain.Category{}
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	path := []web.CategoryPathResponse{{ID: c.ID, Name: c.Name}}
+	current := c
+	for i := 0; current.ParentID != nil && i < len(categories); i++ {
+		parent, ok := byID[*current.ParentID]
+		if !ok {
+			break
+		}
+		path = append([]web.CategoryPathResponse{{ID: parent.ID, Name: parent.Name}}, path...)
+		current = parent
+	}
+	return path
 }
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IdempotencyService interface {
+	// Begin mengklaim key untuk request baru. Jika key sudah pernah dipakai untuk request
+	// yang sama dan sudah selesai, record lama dikembalikan dengan replay = true.
+	Begin(userID int, key, method, path string, body []byte) (record domain.IdempotencyKey, replay bool, err error)
+	Complete(id int, statusCode int, responseBody []byte) error
+	Release(id int) error
+	DeleteExpired() (int64, error)
+}
+
+type idempotencyService struct {
+	Repo repository.IdempotencyKeyRepository
+	TTL  time.Duration
+}
+
+func NewIdempotencyService(repo repository.IdempotencyKeyRepository, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{
+		Repo: repo,
+		TTL:  ttl,
+	}
+}
+
+func (s *idempotencyService) Begin(userID int, key, method, path string, body []byte) (domain.IdempotencyKey, bool, error) {
+	now := time.Now()
+
+	// Key ini dibuang jika sudah lewat masa berlakunya agar bisa dipakai ulang. Key kedaluwarsa
+	// lainnya dibersihkan berkala lewat DeleteExpired.
+	if err := s.Repo.DeleteExpiredKey(userID, key, now); err != nil {
+		return domain.IdempotencyKey{}, false, err
+	}
+
+	fingerprint := requestFingerprint(method, path, body)
+	record, claimed, err := s.Repo.Claim(domain.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(s.TTL),
+	})
+	if err != nil {
+		return domain.IdempotencyKey{}, false, err
+	}
+	if claimed {
+		return record, false, nil
+	}
+
+	existing, err := s.Repo.FindByKey(userID, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Request pemilik key gagal dan melepas key di antara Claim dan FindByKey
+			return domain.IdempotencyKey{}, false, errors.New("idempotency key is still being processed")
+		}
+		return domain.IdempotencyKey{}, false, err
+	}
+	if existing.Fingerprint != fingerprint {
+		return domain.IdempotencyKey{}, false, errors.New("idempotency key reused with different request")
+	}
+	if existing.StatusCode == 0 {
+		return domain.IdempotencyKey{}, false, errors.New("idempotency key is still being processed")
+	}
+	return existing, true, nil
+}
+
+func (s *idempotencyService) Complete(id int, statusCode int, responseBody []byte) error {
+	return s.Repo.Complete(id, statusCode, string(responseBody))
+}
+
+// Release menghapus key milik request yang gagal di sisi server sehingga client boleh retry
+func (s *idempotencyService) Release(id int) error {
+	return s.Repo.Delete(id)
+}
+
+// DeleteExpired membersihkan semua key yang sudah lewat masa berlakunya dan mengembalikan jumlahnya
+func (s *idempotencyService) DeleteExpired() (int64, error) {
+	return s.Repo.DeleteExpired(time.Now())
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
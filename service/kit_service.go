@@ -0,0 +1,275 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type KitService interface {
+	FindKit(productID int, warehouseID int) (web.KitResponse, error)
+	SetComponents(productID int, request web.KitComponentsRequest) (web.KitResponse, error)
+	Assemble(userID int, productID int, request web.KitAssembleRequest) (web.KitAssemblyResponse, error)
+}
+
+type kitService struct {
+	Repo            repository.KitRepository
+	RepoProduct     repository.ProductRepository
+	RepoStock       repository.ProductStockRepository
+	RepoReservation repository.ReservationRepository
+	RepoWarehouse   repository.WarehouseRepository
+	RepoMovement    repository.StockMovementRepository
+	MovementService StockMovementService
+	DB              *gorm.DB
+	Validate        *validator.Validate
+}
+
+func NewKitService(
+	repo repository.KitRepository,
+	repoProduct repository.ProductRepository,
+	repoStock repository.ProductStockRepository,
+	repoReservation repository.ReservationRepository,
+	repoWarehouse repository.WarehouseRepository,
+	repoMovement repository.StockMovementRepository,
+	movementService StockMovementService,
+	db *gorm.DB,
+	validate *validator.Validate,
+) KitService {
+	return &kitService{
+		Repo:            repo,
+		RepoProduct:     repoProduct,
+		RepoStock:       repoStock,
+		RepoReservation: repoReservation,
+		RepoWarehouse:   repoWarehouse,
+		RepoMovement:    repoMovement,
+		MovementService: movementService,
+		DB:              db,
+		Validate:        validate,
+	}
+}
+
+// FindKit mengembalikan bill of materials dan ketersediaan kit. warehouseID 0 berarti
+// ketersediaan dihitung dari stok seluruh gudang.
+func (s *kitService) FindKit(productID int, warehouseID int) (web.KitResponse, error) {
+	kit, err := s.RepoProduct.FindById(productID)
+	if err != nil {
+		return web.KitResponse{}, err
+	}
+	components, err := s.Repo.FindComponents(productID)
+	if err != nil {
+		return web.KitResponse{}, err
+	}
+
+	// available mengembalikan stok fisik dan stok yang ditahan reservasi aktif
+	now := time.Now()
+	var available func(productID int, stock int) (int, int, error)
+	if warehouseID == 0 {
+		reserved, err := s.RepoReservation.SumActiveByProduct(now)
+		if err != nil {
+			return web.KitResponse{}, err
+		}
+		available = func(productID int, stock int) (int, int, error) {
+			return stock, reserved[productID], nil
+		}
+	} else {
+		if _, err := s.RepoWarehouse.FindById(warehouseID); err != nil {
+			return web.KitResponse{}, errors.New("warehouse not found")
+		}
+		available = func(productID int, _ int) (int, int, error) {
+			stock, err := s.RepoStock.FindByProductAndWarehouse(productID, warehouseID)
+			if err != nil {
+				return 0, 0, err
+			}
+			reserved, err := s.RepoReservation.SumActiveByWarehouse(productID, now)
+			if err != nil {
+				return 0, 0, err
+			}
+			return stock.Stock, reserved[warehouseID], nil
+		}
+	}
+
+	response := web.KitResponse{
+		ProductID:  kit.ID,
+		Name:       kit.Name,
+		Components: []web.KitComponentResponse{},
+	}
+	if warehouseID != 0 {
+		response.WarehouseID = &warehouseID
+	}
+	response.Assembled, response.Reserved, err = available(kit.ID, kit.Stock)
+	if err != nil {
+		return web.KitResponse{}, err
+	}
+
+	componentAvailable := map[int]int{}
+	for _, c := range components {
+		stock, reserved, err := available(c.ComponentID, c.Component.Stock)
+		if err != nil {
+			return web.KitResponse{}, err
+		}
+		componentAvailable[c.ComponentID] = stock - reserved
+	}
+	response.Components, response.Buildable = toKitComponentResponses(components, componentAvailable)
+	response.Available = response.Assembled - response.Reserved + response.Buildable
+	return response, nil
+}
+
+// SetComponents mengganti bill of materials produk. Kit dan komponennya tidak boleh produk
+// serialized atau produk induk varian, dan komponen tidak boleh berupa kit lain.
+func (s *kitService) SetComponents(productID int, req web.KitComponentsRequest) (web.KitResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.KitResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	kit, err := s.RepoProduct.FindById(productID)
+	if err != nil {
+		return web.KitResponse{}, err
+	}
+
+	var components []domain.KitComponent
+	if len(req.Components) > 0 {
+		if err := s.checkKitProduct(kit); err != nil {
+			return web.KitResponse{}, err
+		}
+		seen := map[int]bool{}
+		for _, c := range req.Components {
+			if c.ProductID == productID {
+				return web.KitResponse{}, errors.New("kit cannot contain itself")
+			}
+			if seen[c.ProductID] {
+				return web.KitResponse{}, errors.New("duplicate kit component")
+			}
+			seen[c.ProductID] = true
+			if err := s.checkComponent(c.ProductID); err != nil {
+				return web.KitResponse{}, err
+			}
+			components = append(components, domain.KitComponent{KitID: productID, ComponentID: c.ProductID, Quantity: c.Quantity})
+		}
+	}
+
+	if err := s.Repo.ReplaceComponents(productID, components); err != nil {
+		return web.KitResponse{}, err
+	}
+	return s.FindKit(productID, 0)
+}
+
+// Assemble merakit kit dari stok komponen di satu gudang: komponen diposting sebagai
+// movement out dan kit sebagai movement in dalam satu transaksi.
+func (s *kitService) Assemble(userID int, productID int, req web.KitAssembleRequest) (web.KitAssemblyResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.KitAssemblyResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+
+	var assembly domain.KitAssembly
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		assembly, _, err = s.MovementService.Assemble(tx, domain.KitAssembly{
+			KitID:       productID,
+			WarehouseID: req.WarehouseID,
+			Quantity:    req.Quantity,
+			UserID:      userID,
+			Note:        req.Note,
+		})
+		return err
+	})
+	if err != nil {
+		return web.KitAssemblyResponse{}, err
+	}
+
+	movements, err := s.RepoMovement.FindByReference(kitAssemblyReference(assembly.ID))
+	if err != nil {
+		return web.KitAssemblyResponse{}, err
+	}
+	response := web.KitAssemblyResponse{
+		ID:          assembly.ID,
+		KitID:       assembly.KitID,
+		WarehouseID: assembly.WarehouseID,
+		Quantity:    assembly.Quantity,
+		Automatic:   assembly.Automatic,
+		UserID:      assembly.UserID,
+		Note:        assembly.Note,
+		CreatedAt:   assembly.CreatedAt,
+		Movements:   []web.StockMovementResponse{},
+	}
+	for _, m := range movements {
+		response.Movements = append(response.Movements, toStockMovementResponse(m))
+	}
+	return response, nil
+}
+
+func (s *kitService) checkKitProduct(kit domain.Product) error {
+	if kit.Serialized {
+		return errors.New("serialized product cannot be a kit")
+	}
+	hasVariants, err := s.RepoProduct.HasVariants(kit.ID)
+	if err != nil {
+		return err
+	}
+	if hasVariants {
+		return errors.New("parent product cannot be a kit")
+	}
+	isComponent, err := s.Repo.IsComponent(kit.ID)
+	if err != nil {
+		return err
+	}
+	if isComponent {
+		return errors.New("kit component cannot be a kit")
+	}
+	return nil
+}
+
+func (s *kitService) checkComponent(productID int) error {
+	component, err := s.RepoProduct.FindById(productID)
+	if err != nil {
+		return errors.New("component product not found")
+	}
+	if component.Serialized {
+		return errors.New("serialized product cannot be a kit component")
+	}
+	hasVariants, err := s.RepoProduct.HasVariants(productID)
+	if err != nil {
+		return err
+	}
+	if hasVariants {
+		return errors.New("parent product cannot be a kit component")
+	}
+	children, err := s.Repo.FindComponents(productID)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return errors.New("kit component cannot be a kit")
+	}
+	return nil
+}
+
+// toKitComponentResponses membuat response komponen kit beserta jumlah kit yang bisa dirakit,
+// yaitu nilai terkecil dari stok tersedia setiap komponen dibagi quantity-nya
+func toKitComponentResponses(components []domain.KitComponent, available map[int]int) ([]web.KitComponentResponse, int) {
+	responses := []web.KitComponentResponse{}
+	buildable := 0
+	for i, c := range components {
+		componentBuildable := max(available[c.ComponentID], 0) / c.Quantity
+		if i == 0 || componentBuildable < buildable {
+			buildable = componentBuildable
+		}
+
+		response := web.KitComponentResponse{
+			ProductID: c.ComponentID,
+			Name:      c.Component.Name,
+			Quantity:  c.Quantity,
+			Available: available[c.ComponentID],
+			Buildable: componentBuildable,
+		}
+		if c.Component.SKU != nil {
+			response.SKU = *c.Component.SKU
+		}
+		responses = append(responses, response)
+	}
+	return responses, buildable
+}
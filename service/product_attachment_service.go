@@ -0,0 +1,265 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"inventory-management-api/helper"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"inventory-management-api/storage"
+	"io"
+	"io/fs"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// maxImagePixels membatasi ukuran gambar yang di-decode agar tidak menghabiskan memori
+	maxImagePixels = 40_000_000
+	// thumbnailSize adalah panjang sisi terpanjang thumbnail dalam piksel
+	thumbnailSize = 256
+)
+
+// attachmentTypes berisi content type yang diterima per jenis lampiran beserta ekstensi
+// berkasnya. Content type ditentukan dari isi berkas, bukan dari header yang dikirim client.
+var attachmentTypes = map[string]map[string]string{
+	"image": {
+		"image/jpeg": ".jpg",
+		"image/png":  ".png",
+		"image/gif":  ".gif",
+	},
+	"document": {
+		"application/pdf": ".pdf",
+	},
+}
+
+type ProductAttachmentService interface {
+	FindByProduct(productID int) ([]web.ProductAttachmentResponse, error)
+	Upload(productID int, userID int, kind string, file *multipart.FileHeader) (web.ProductAttachmentResponse, error)
+	Open(productID int, id int, thumbnail bool) (web.ProductAttachmentResponse, io.ReadCloser, error)
+	Delete(productID int, id int) error
+}
+
+type productAttachmentService struct {
+	Repo        repository.ProductAttachmentRepository
+	RepoProduct repository.ProductRepository
+	Storage     storage.Storage
+	MaxSize     int64
+}
+
+func NewProductAttachmentService(repo repository.ProductAttachmentRepository, repoProduct repository.ProductRepository, fileStorage storage.Storage, maxSize int64) ProductAttachmentService {
+	return &productAttachmentService{
+		Repo:        repo,
+		RepoProduct: repoProduct,
+		Storage:     fileStorage,
+		MaxSize:     maxSize,
+	}
+}
+
+func (s *productAttachmentService) FindByProduct(productID int) ([]web.ProductAttachmentResponse, error) {
+	if _, err := s.RepoProduct.FindById(productID); err != nil {
+		return nil, err
+	}
+	attachments, err := s.Repo.FindByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []web.ProductAttachmentResponse{}
+	for _, a := range attachments {
+		responses = append(responses, toProductAttachmentResponse(a))
+	}
+	return responses, nil
+}
+
+// Upload memvalidasi dan menyimpan berkas sebagai lampiran produk. kind adalah "image" atau
+// "document". Untuk gambar dibuat juga thumbnail JPEG.
+func (s *productAttachmentService) Upload(productID int, userID int, kind string, file *multipart.FileHeader) (web.ProductAttachmentResponse, error) {
+	allowedTypes, ok := attachmentTypes[kind]
+	if !ok {
+		return web.ProductAttachmentResponse{}, errors.New("invalid attachment kind")
+	}
+	if _, err := s.RepoProduct.FindById(productID); err != nil {
+		return web.ProductAttachmentResponse{}, err
+	}
+	if file.Size == 0 {
+		return web.ProductAttachmentResponse{}, errors.New("file is empty")
+	}
+	if file.Size > s.MaxSize {
+		return web.ProductAttachmentResponse{}, errors.New("file too large")
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return web.ProductAttachmentResponse{}, err
+	}
+	defer f.Close()
+	content, err := io.ReadAll(io.LimitReader(f, s.MaxSize+1))
+	if err != nil {
+		return web.ProductAttachmentResponse{}, err
+	}
+	if int64(len(content)) > s.MaxSize {
+		return web.ProductAttachmentResponse{}, errors.New("file too large")
+	}
+
+	contentType := http.DetectContentType(content)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return web.ProductAttachmentResponse{}, errors.New("unsupported file type")
+	}
+
+	name, err := randomFileName()
+	if err != nil {
+		return web.ProductAttachmentResponse{}, err
+	}
+	attachment := domain.ProductAttachment{
+		ProductID:   productID,
+		Kind:        kind,
+		FileName:    attachmentFileName(file.Filename),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		StorageKey:  fmt.Sprintf("products/%d/%s%s", productID, name, ext),
+		UserID:      userID,
+	}
+
+	var thumbnail bytes.Buffer
+	if kind == "image" {
+		config, _, err := image.DecodeConfig(bytes.NewReader(content))
+		if err != nil {
+			return web.ProductAttachmentResponse{}, errors.New("invalid image")
+		}
+		if config.Width*config.Height > maxImagePixels {
+			return web.ProductAttachmentResponse{}, errors.New("image dimensions too large")
+		}
+		img, _, err := image.Decode(bytes.NewReader(content))
+		if err != nil {
+			return web.ProductAttachmentResponse{}, errors.New("invalid image")
+		}
+		if err := jpeg.Encode(&thumbnail, helper.Thumbnail(img, thumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+			return web.ProductAttachmentResponse{}, err
+		}
+		attachment.Width = config.Width
+		attachment.Height = config.Height
+		attachment.ThumbnailKey = fmt.Sprintf("products/%d/%s_thumb.jpg", productID, name)
+	}
+
+	if err := s.Storage.Put(attachment.StorageKey, bytes.NewReader(content), contentType); err != nil {
+		return web.ProductAttachmentResponse{}, err
+	}
+	if attachment.ThumbnailKey != "" {
+		if err := s.Storage.Put(attachment.ThumbnailKey, &thumbnail, "image/jpeg"); err != nil {
+			deleteAttachmentFiles(s.Storage, domain.ProductAttachment{StorageKey: attachment.StorageKey})
+			return web.ProductAttachmentResponse{}, err
+		}
+	}
+
+	saved, err := s.Repo.Save(attachment)
+	if err != nil {
+		deleteAttachmentFiles(s.Storage, attachment)
+		return web.ProductAttachmentResponse{}, err
+	}
+	return toProductAttachmentResponse(saved), nil
+}
+
+// Open membuka berkas lampiran, atau thumbnail-nya jika thumbnail true, beserta metadata
+// lampiran untuk header response. Pemanggil wajib menutup berkasnya.
+func (s *productAttachmentService) Open(productID int, id int, thumbnail bool) (web.ProductAttachmentResponse, io.ReadCloser, error) {
+	attachment, err := s.Repo.FindById(productID, id)
+	if err != nil {
+		return web.ProductAttachmentResponse{}, nil, errors.New("attachment not found")
+	}
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			return web.ProductAttachmentResponse{}, nil, errors.New("attachment has no thumbnail")
+		}
+		key = attachment.ThumbnailKey
+		attachment.ContentType = "image/jpeg"
+	}
+
+	file, err := s.Storage.Open(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return web.ProductAttachmentResponse{}, nil, errors.New("attachment file not found")
+	}
+	if err != nil {
+		return web.ProductAttachmentResponse{}, nil, err
+	}
+	return toProductAttachmentResponse(attachment), file, nil
+}
+
+func (s *productAttachmentService) Delete(productID int, id int) error {
+	attachment, err := s.Repo.FindById(productID, id)
+	if err != nil {
+		return errors.New("attachment not found")
+	}
+	if err := s.Repo.Delete(productID, id); err != nil {
+		return err
+	}
+	deleteAttachmentFiles(s.Storage, attachment)
+	return nil
+}
+
+// deleteAttachmentFiles menghapus berkas lampiran dan thumbnail-nya dari storage. Kegagalan
+// hanya dicatat di log karena data lampiran sudah terhapus.
+func deleteAttachmentFiles(fileStorage storage.Storage, attachment domain.ProductAttachment) {
+	for _, key := range []string{attachment.StorageKey, attachment.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := fileStorage.Delete(key); err != nil {
+			log.Printf("[WARNING] Gagal menghapus berkas lampiran %s: %v", key, err)
+		}
+	}
+}
+
+// randomFileName membuat nama berkas acak agar URL lampiran tidak bisa ditebak
+func randomFileName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// attachmentFileName mengambil nama berkas asli tanpa path, dibatasi 255 karakter
+func attachmentFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "." || name == "/" {
+		name = "file"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
+
+// toProductAttachmentResponse membentuk URL unduhan dari ID lampiran, sehingga URL tidak
+// bergantung pada lokasi penyimpanan berkas
+func toProductAttachmentResponse(a domain.ProductAttachment) web.ProductAttachmentResponse {
+	response := web.ProductAttachmentResponse{
+		ID:          a.ID,
+		Kind:        a.Kind,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		URL:         fmt.Sprintf("/products/%d/attachments/%d/file", a.ProductID, a.ID),
+		Width:       a.Width,
+		Height:      a.Height,
+		CreatedAt:   a.CreatedAt,
+	}
+	if a.ThumbnailKey != "" {
+		response.ThumbnailURL = fmt.Sprintf("/products/%d/attachments/%d/thumbnail", a.ProductID, a.ID)
+	}
+	return response
+}
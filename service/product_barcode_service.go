@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type ProductBarcodeService interface {
+	FindByProduct(productID int) ([]web.ProductBarcodeResponse, error)
+	Create(productID int, request web.ProductBarcodeCreateRequest) (web.ProductBarcodeResponse, error)
+	Delete(productID, id int) error
+}
+
+type productBarcodeService struct {
+	Repo        repository.ProductBarcodeRepository
+	RepoProduct repository.ProductRepository
+	Validate    *validator.Validate
+}
+
+func NewProductBarcodeService(repo repository.ProductBarcodeRepository, repoProduct repository.ProductRepository, validate *validator.Validate) ProductBarcodeService {
+	return &productBarcodeService{
+		Repo:        repo,
+		RepoProduct: repoProduct,
+		Validate:    validate,
+	}
+}
+
+func (s *productBarcodeService) FindByProduct(productID int) ([]web.ProductBarcodeResponse, error) {
+	if _, err := s.RepoProduct.FindById(productID); err != nil {
+		return nil, errors.New("product not found")
+	}
+	barcodes, err := s.Repo.FindByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []web.ProductBarcodeResponse{}
+	for _, b := range barcodes {
+		responses = append(responses, toProductBarcodeResponse(b))
+	}
+	return responses, nil
+}
+
+// Create menambahkan barcode ke produk setelah format dan check digit-nya divalidasi.
+// Barcode tidak boleh sama dengan SKU atau barcode produk mana pun.
+func (s *productBarcodeService) Create(productID int, req web.ProductBarcodeCreateRequest) (web.ProductBarcodeResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.ProductBarcodeResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	if _, err := s.RepoProduct.FindById(productID); err != nil {
+		return web.ProductBarcodeResponse{}, errors.New("product not found")
+	}
+
+	code := strings.TrimSpace(req.Code)
+	symbology := req.Symbology
+	if symbology == "" {
+		symbology = detectSymbology(code)
+	}
+	if err := validateBarcode(code, symbology); err != nil {
+		return web.ProductBarcodeResponse{}, err
+	}
+	if _, err := s.RepoProduct.FindByCodeWithTrashed(code); err == nil {
+		return web.ProductBarcodeResponse{}, errors.New("barcode already in use")
+	} else if err.Error() != "product not found" {
+		return web.ProductBarcodeResponse{}, err
+	}
+
+	saved, err := s.Repo.Save(domain.ProductBarcode{ProductID: productID, Code: code, Symbology: symbology})
+	if err != nil {
+		// Request paralel dengan kode yang sama lolos pengecekan di atas dan ditolak unique index
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return web.ProductBarcodeResponse{}, errors.New("barcode already in use")
+		}
+		return web.ProductBarcodeResponse{}, err
+	}
+	return toProductBarcodeResponse(saved), nil
+}
+
+func (s *productBarcodeService) Delete(productID, id int) error {
+	if err := s.Repo.Delete(productID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("barcode not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func toProductBarcodeResponse(b domain.ProductBarcode) web.ProductBarcodeResponse {
+	return web.ProductBarcodeResponse{ID: b.ID, Code: b.Code, Symbology: b.Symbology}
+}
+
+// detectSymbology menebak jenis barcode dari kodenya: 13 digit EAN-13, 12 digit UPC-A,
+// selain itu Code128
+func detectSymbology(code string) string {
+	if isDigits(code) {
+		switch len(code) {
+		case 13:
+			return "ean13"
+		case 12:
+			return "upca"
+		}
+	}
+	return "code128"
+}
+
+// validateBarcode memeriksa panjang, karakter, dan check digit barcode. Check digit Code128
+// tidak ikut terbaca dari scanner (sudah diverifikasi scanner), jadi yang diperiksa hanya
+// karakternya (ASCII yang bisa dicetak).
+func validateBarcode(code, symbology string) error {
+	switch symbology {
+	case "ean13":
+		if len(code) != 13 || !isDigits(code) {
+			return errors.New("invalid barcode: EAN-13 must be 13 digits")
+		}
+	case "upca":
+		if len(code) != 12 || !isDigits(code) {
+			return errors.New("invalid barcode: UPC-A must be 12 digits")
+		}
+	case "code128":
+		for _, r := range code {
+			if r < 32 || r > 126 {
+				return errors.New("invalid barcode: Code128 only allows printable ASCII")
+			}
+		}
+		return nil
+	}
+
+	if gtinCheckDigit(code[:len(code)-1]) != int(code[len(code)-1]-'0') {
+		return errors.New("invalid barcode: check digit mismatch")
+	}
+	return nil
+}
+
+// gtinCheckDigit menghitung check digit GTIN (EAN-13/UPC-A): dari digit paling kanan,
+// digit berbobot 3 dan 1 bergantian, check digit = (10 - jumlah mod 10) mod 10
+func gtinCheckDigit(digits string) int {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -6,56 +6,141 @@ import (
 	"inventory-management-api/model/domain"
 	"inventory-management-api/model/web"
 	"inventory-management-api/repository"
+	"inventory-management-api/storage"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
 type ProductService interface {
-	FindAll() ([]web.ProductResponse, error)
-	FindById(id int) (web.ProductResponse, error)
+	FindAll(filters map[string]interface{}) ([]web.ProductResponse, error)
+	FindById(id int, unit string) (web.ProductResponse, error)
 	Create(request web.ProductCreateOrUpdateRequest) (web.ProductResponse, error)
 	Update(id int, request web.ProductCreateOrUpdateRequest) (web.ProductResponse, error)
 	Delete(id int) error
 	SearchWithFilter(name, sort string, page, limit int) ([]web.ProductResponse, error)
+	FindLowStock() ([]web.LowStockProductResponse, error)
+	FindVariants(parentID int) ([]web.ProductResponse, error)
+	CreateVariant(parentID int, request web.ProductVariantCreateRequest) (web.ProductResponse, error)
+	Lookup(code string) (web.ProductResponse, error)
+	FindTrashed() ([]web.ProductResponse, error)
+	Restore(id int) (web.ProductResponse, error)
+	Purge(id int) (web.PurgeResponse, error)
 }
 
 type productService struct {
-	Repo     repository.ProductRepository
-	Validate *validator.Validate
+	Repo            repository.ProductRepository
+	RepoStock       repository.ProductStockRepository
+	RepoReservation repository.ReservationRepository
+	RepoAlert       repository.StockAlertRepository
+	RepoUnit        repository.UnitRepository
+	RepoCategory    repository.CategoryRepository
+	RepoKit         repository.KitRepository
+	RepoSerial      repository.SerialNumberRepository
+	Storage         storage.Storage
+	Validate        *validator.Validate
 }
 
-func NewProductService(repo repository.ProductRepository, validate *validator.Validate) ProductService {
+func NewProductService(repo repository.ProductRepository, repoStock repository.ProductStockRepository, repoReservation repository.ReservationRepository, repoAlert repository.StockAlertRepository, repoUnit repository.UnitRepository, repoCategory repository.CategoryRepository, repoKit repository.KitRepository, repoSerial repository.SerialNumberRepository, fileStorage storage.Storage, validate *validator.Validate) ProductService {
 	return &productService{
-		Repo:     repo,
-		Validate: validate,
+		Repo:            repo,
+		RepoStock:       repoStock,
+		RepoReservation: repoReservation,
+		RepoAlert:       repoAlert,
+		RepoUnit:        repoUnit,
+		RepoCategory:    repoCategory,
+		RepoKit:         repoKit,
+		RepoSerial:      repoSerial,
+		Storage:         fileStorage,
+		Validate:        validate,
 	}
 }
 
-func (s *productService) FindAll() ([]web.ProductResponse, error) {
-	products, err := s.Repo.FindAll()
+func (s *productService) FindAll(filters map[string]interface{}) ([]web.ProductResponse, error) {
+	products, err := s.Repo.FindAll(filters)
 	if err != nil {
 		return nil, err
 	}
-	return toProductResponses(products), nil
+	return s.withReserved(toProductResponses(products))
 }
 
-func (s *productService) FindById(id int) (web.ProductResponse, error) {
+func (s *productService) FindById(id int, unit string) (web.ProductResponse, error) {
 	p, err := s.Repo.FindById(id)
 	if err != nil {
 		return web.ProductResponse{}, fmt.Errorf("product not found")
 	}
-	return toProductResponse(p), nil
+
+	// Rincian stok per gudang, total tetap diambil dari Product.Stock
+	stocks, err := s.RepoStock.FindByProductId(id)
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	reserved, err := s.RepoReservation.SumActiveByWarehouse(id, time.Now())
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+
+	response := toProductResponse(p)
+	for _, st := range stocks {
+		response.Reserved += reserved[st.WarehouseID]
+		response.Warehouses = append(response.Warehouses, web.ProductWarehouseStockResponse{
+			WarehouseID: st.WarehouseID,
+			Warehouse:   st.Warehouse.Name,
+			Stock:       st.Stock,
+			Reserved:    reserved[st.WarehouseID],
+			Available:   st.Stock - reserved[st.WarehouseID],
+		})
+	}
+	response.Available = response.OnHand - response.Reserved
+
+	// Stok produk induk adalah jumlah stok variannya
+	hasVariants, err := s.Repo.HasVariants(id)
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	if hasVariants {
+		responses, err := s.withReserved([]web.ProductResponse{response})
+		if err != nil {
+			return web.ProductResponse{}, err
+		}
+		response = responses[0]
+	}
+
+	// Stok ditampilkan juga dalam satuan yang diminta
+	if unit != "" {
+		_, factor, err := resolveUnit(s.RepoUnit, p, unit)
+		if err != nil {
+			return web.ProductResponse{}, err
+		}
+		response.Display = &web.ProductDisplayStockResponse{
+			Unit:      unit,
+			OnHand:    inUnit(response.OnHand, factor),
+			Reserved:  inUnit(response.Reserved, factor),
+			Available: inUnit(response.Available, factor),
+		}
+	}
+	return response, nil
 }
 
 func (s *productService) Create(req web.ProductCreateOrUpdateRequest) (web.ProductResponse, error) {
 	if err := s.Validate.Struct(req); err != nil {
 		return web.ProductResponse{}, fmt.Errorf("validation error: %w", err)
 	}
+	if err := s.checkSKU(req.SKU, 0); err != nil {
+		return web.ProductResponse{}, err
+	}
 
 	product := domain.Product{
 		Name:       req.Name,
 		CategoryID: req.CategoryID,
-		Stock:      req.Stock,
+		Serialized: req.Serialized,
+		SKU:        optionalSKU(req.SKU),
+
+		MinStock:        req.MinStock,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
 	}
 
 	saved, err := s.Repo.Save(product)
@@ -71,24 +156,62 @@ func (s *productService) Update(id int, req web.ProductCreateOrUpdateRequest) (w
 	}
 
 	// pastikan data lama ada
-	_, err := s.Repo.FindById(id)
+	existing, err := s.Repo.FindById(id)
 	if err != nil {
 		return web.ProductResponse{}, errors.New("product not found")
 	}
 
+	// Kategori dan serialized varian selalu mengikuti induknya
+	categoryID := req.CategoryID
+	serialized := req.Serialized
+	if existing.ParentID != nil {
+		parent, err := s.Repo.FindById(*existing.ParentID)
+		if err != nil {
+			return web.ProductResponse{}, err
+		}
+		categoryID = parent.CategoryID
+		serialized = parent.Serialized
+	}
+	if err := s.checkSKU(req.SKU, id); err != nil {
+		return web.ProductResponse{}, err
+	}
+	hasVariants, err := s.Repo.HasVariants(id)
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	if serialized != existing.Serialized {
+		if err := s.checkSerializedChange(existing, serialized, hasVariants); err != nil {
+			return web.ProductResponse{}, err
+		}
+	}
+
 	product := domain.Product{
 		ID:         id,
 		Name:       req.Name,
-		CategoryID: req.CategoryID,
-		Stock:      req.Stock,
+		CategoryID: categoryID,
+		Serialized: serialized,
+		SKU:        optionalSKU(req.SKU),
+
+		MinStock:        req.MinStock,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
 	}
 
 	updated, err := s.Repo.Update(product)
 	if err != nil {
 		return web.ProductResponse{}, err
 	}
+	if hasVariants {
+		if err := s.Repo.UpdateVariantCategory(id, categoryID); err != nil {
+			return web.ProductResponse{}, err
+		}
+	}
 
-	return toProductResponse(updated), nil
+	responses, err := s.withReserved([]web.ProductResponse{toProductResponse(updated)})
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	return responses[0], nil
 }
 
 func (s *productService) Delete(id int) error {
@@ -96,9 +219,81 @@ func (s *productService) Delete(id int) error {
 	if err != nil {
 		return errors.New("product not found")
 	}
+	if err := checkProductDeletable(s.Repo, s.RepoKit, id, nil); err != nil {
+		return err
+	}
 	return s.Repo.Delete(id)
 }
 
+// checkSerializedChange menolak perubahan serialized selama produk masih punya stok atau serial
+// number, karena stok lama tidak punya serial dan serial lama akan tertinggal. Produk induk
+// varian dan produk yang terkait kit juga tidak boleh diubah.
+func (s *productService) checkSerializedChange(product domain.Product, serialized bool, hasVariants bool) error {
+	if hasVariants {
+		return errors.New("parent product cannot change serialized")
+	}
+	if product.Stock != 0 {
+		return errors.New("cannot change serialized while product has stock")
+	}
+	stocks, err := s.RepoStock.FindByProductId(product.ID)
+	if err != nil {
+		return err
+	}
+	for _, st := range stocks {
+		if st.Stock != 0 {
+			return errors.New("cannot change serialized while product has stock")
+		}
+	}
+	serials, err := s.RepoSerial.CountByProduct(product.ID)
+	if err != nil {
+		return err
+	}
+	if serials > 0 {
+		return errors.New("cannot change serialized while product has serial numbers")
+	}
+
+	if serialized {
+		components, err := s.RepoKit.FindComponents(product.ID)
+		if err != nil {
+			return err
+		}
+		if len(components) > 0 {
+			return errors.New("serialized product cannot be a kit")
+		}
+		isComponent, err := s.RepoKit.IsComponent(product.ID)
+		if err != nil {
+			return err
+		}
+		if isComponent {
+			return errors.New("serialized product cannot be a kit component")
+		}
+	}
+	return nil
+}
+
+// checkProductDeletable menolak penghapusan produk yang masih punya varian atau masih dipakai
+// sebagai komponen kit. deleting berisi produk lain yang ikut dihapus bersamaan, sehingga
+// produk induk boleh dihapus jika semua variannya ikut terhapus.
+func checkProductDeletable(repoProduct repository.ProductRepository, repoKit repository.KitRepository, id int, deleting map[int]bool) error {
+	variants, err := repoProduct.FindVariants(id)
+	if err != nil {
+		return err
+	}
+	for _, v := range variants {
+		if !deleting[v.ID] {
+			return errors.New("product has variants")
+		}
+	}
+	isComponent, err := repoKit.IsComponent(id)
+	if err != nil {
+		return err
+	}
+	if isComponent {
+		return errors.New("product is used as kit component")
+	}
+	return nil
+}
+
 func (s *productService) SearchWithFilter(name, sort string, page, limit int) ([]web.ProductResponse, error) {
 	products, err := s.Repo.SearchWithFilter(name, sort, page, limit)
 	if err != nil {
@@ -107,18 +302,311 @@ func (s *productService) SearchWithFilter(name, sort string, page, limit int) ([
 	if len(products) == 0 {
 		return nil, errors.New("no products found")
 	}
+	return s.withReserved(toProductResponses(products))
+}
+
+// FindLowStock mengambil produk yang stoknya di bawah ambang batas beserta alert yang masih open
+func (s *productService) FindLowStock() ([]web.LowStockProductResponse, error) {
+	products, err := s.Repo.FindLowStock()
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := s.RepoAlert.FindOpen()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []web.LowStockProductResponse{}
+	for _, p := range products {
+		threshold := lowStockThreshold(p)
+		response := web.LowStockProductResponse{
+			ProductID:       p.ID,
+			Name:            p.Name,
+			Stock:           p.Stock,
+			MinStock:        p.MinStock,
+			ReorderPoint:    p.ReorderPoint,
+			ReorderQuantity: p.ReorderQuantity,
+			Threshold:       threshold,
+			Shortage:        threshold - p.Stock,
+		}
+		if alert, ok := alerts[p.ID]; ok {
+			response.AlertID = &alert.ID
+			response.AlertRaisedAt = &alert.CreatedAt
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func (s *productService) FindVariants(parentID int) ([]web.ProductResponse, error) {
+	if _, err := s.Repo.FindById(parentID); err != nil {
+		return nil, errors.New("product not found")
+	}
+	variants, err := s.Repo.FindVariants(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := toProductResponses(variants)
+	if responses == nil {
+		responses = []web.ProductResponse{}
+	}
+	return s.withReserved(responses)
+}
+
+// CreateVariant membuat varian dari produk induk. Varian mewarisi kategori, serialized,
+// dan satuan dasar induk, dan dibuat dengan stok 0. Produk yang sudah punya stok sendiri
+// atau yang merupakan varian tidak bisa dijadikan induk.
+func (s *productService) CreateVariant(parentID int, req web.ProductVariantCreateRequest) (web.ProductResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.ProductResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	parent, err := s.Repo.FindById(parentID)
+	if err != nil {
+		return web.ProductResponse{}, errors.New("product not found")
+	}
+	if parent.ParentID != nil {
+		return web.ProductResponse{}, errors.New("variant cannot have variants")
+	}
+	if parent.Stock != 0 {
+		return web.ProductResponse{}, errors.New("product with stock cannot have variants")
+	}
+
+	if err := s.checkSKU(req.SKU, 0); err != nil {
+		return web.ProductResponse{}, err
+	}
+
+	attributes := normalizeAttributes(req.Attributes)
+	siblings, err := s.Repo.FindVariants(parentID)
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	for _, sibling := range siblings {
+		if sameAttributes(attributes, sibling.Attributes) {
+			return web.ProductResponse{}, errors.New("variant attribute combination already exists")
+		}
+	}
+
+	name := req.Name
+	if name == "" {
+		name = parent.Name + " (" + attributeValues(attributes) + ")"
+	}
+	variant := domain.Product{
+		Name:       name,
+		CategoryID: parent.CategoryID,
+		Serialized: parent.Serialized,
+		SKU:        optionalSKU(req.SKU),
+		ParentID:   &parent.ID,
+		BaseUnitID: parent.BaseUnitID,
+
+		MinStock:        req.MinStock,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+	}
+	for attributeName, value := range attributes {
+		variant.Attributes = append(variant.Attributes, domain.ProductAttribute{Name: attributeName, Value: value})
+	}
+
+	saved, err := s.Repo.Save(variant)
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	return toProductResponse(saved), nil
+}
+
+// Lookup mencari produk berdasarkan SKU atau barcode, misalnya hasil scan
+func (s *productService) Lookup(code string) (web.ProductResponse, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return web.ProductResponse{}, errors.New("code is required")
+	}
+	product, err := s.Repo.FindByCode(code)
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	return s.FindById(product.ID, "")
+}
+
+// FindTrashed mengambil produk yang sudah dihapus dan masih bisa dipulihkan
+func (s *productService) FindTrashed() ([]web.ProductResponse, error) {
+	products, err := s.Repo.FindTrashed()
+	if err != nil {
+		return nil, err
+	}
 	return toProductResponses(products), nil
 }
 
+// Restore memulihkan produk dari trash. Produk induk dan kategorinya harus dipulihkan lebih
+// dulu jika ikut terhapus.
+func (s *productService) Restore(id int) (web.ProductResponse, error) {
+	product, err := s.Repo.FindTrashedById(id)
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	if product.ParentID != nil {
+		if _, err := s.Repo.FindById(*product.ParentID); err != nil {
+			return web.ProductResponse{}, errors.New("parent product is deleted")
+		}
+	}
+	if _, err := s.RepoCategory.FindById(product.CategoryID); err != nil {
+		return web.ProductResponse{}, errors.New("product category is deleted")
+	}
+
+	if err := s.Repo.Restore(id); err != nil {
+		return web.ProductResponse{}, err
+	}
+	return s.FindById(id, "")
+}
+
+// Purge menghapus permanen produk yang sudah ada di trash. Ditolak selama produk masih
+// dirujuk transaksi (movement, reservasi, order, stocktake) atau varian.
+func (s *productService) Purge(id int) (web.PurgeResponse, error) {
+	product, err := s.Repo.FindTrashedById(id)
+	if err != nil {
+		return web.PurgeResponse{}, err
+	}
+	references, err := s.Repo.CountReferences(id)
+	if err != nil {
+		return web.PurgeResponse{}, err
+	}
+	response := web.PurgeResponse{ID: id, References: references}
+	if len(references) > 0 {
+		return response, errors.New("product is still referenced")
+	}
+	if err := s.Repo.Purge(id); err != nil {
+		return web.PurgeResponse{}, err
+	}
+	for _, a := range product.Attachments {
+		deleteAttachmentFiles(s.Storage, a)
+	}
+	return response, nil
+}
+
+// checkSKU memastikan SKU belum dipakai produk lain, baik sebagai SKU maupun barcode.
+// productID 0 berarti produk baru.
+func (s *productService) checkSKU(sku string, productID int) error {
+	code := optionalSKU(sku)
+	if code == nil {
+		return nil
+	}
+	owner, err := s.Repo.FindByCodeWithTrashed(*code)
+	if err == nil && owner.ID != productID {
+		return errors.New("sku already in use")
+	}
+	return nil
+}
+
+// withReserved mengisi reserved dan available dari reservasi yang masih aktif. Produk induk
+// tidak punya stok sendiri, stok dan reserved-nya adalah jumlah dari seluruh variannya.
+// Available kit ditambah jumlah kit yang masih bisa dirakit dari stok komponennya.
+func (s *productService) withReserved(responses []web.ProductResponse) ([]web.ProductResponse, error) {
+	reserved, err := s.RepoReservation.SumActiveByProduct(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	variants, err := s.Repo.FindAllVariants()
+	if err != nil {
+		return nil, err
+	}
+
+	variantCount := map[int]int{}
+	variantStock := map[int]int{}
+	variantReserved := map[int]int{}
+	for _, v := range variants {
+		variantCount[*v.ParentID]++
+		variantStock[*v.ParentID] += v.Stock
+		variantReserved[*v.ParentID] += reserved[v.ID]
+	}
+
+	components, err := s.RepoKit.FindAllComponents()
+	if err != nil {
+		return nil, err
+	}
+	kitComponents := map[int][]domain.KitComponent{}
+	componentAvailable := map[int]int{}
+	for _, c := range components {
+		kitComponents[c.KitID] = append(kitComponents[c.KitID], c)
+		componentAvailable[c.ComponentID] = c.Component.Stock - reserved[c.ComponentID]
+	}
+
+	for i := range responses {
+		id := responses[i].ID
+		responses[i].Reserved = reserved[id]
+		if count, ok := variantCount[id]; ok {
+			responses[i].VariantCount = count
+			responses[i].Stock = variantStock[id]
+			responses[i].OnHand = variantStock[id]
+			responses[i].Reserved = variantReserved[id]
+		}
+		if kit, ok := kitComponents[id]; ok {
+			responses[i].Components, responses[i].Buildable = toKitComponentResponses(kit, componentAvailable)
+		}
+		responses[i].Available = responses[i].OnHand - responses[i].Reserved + responses[i].Buildable
+	}
+	return responses, nil
+}
+
 // Helpers
 
 func toProductResponse(p domain.Product) web.ProductResponse {
-	return web.ProductResponse{
+	response := web.ProductResponse{
 		ID:         p.ID,
 		Name:       p.Name,
 		CategoryID: p.CategoryID,
 		Stock:      p.Stock,
+		Serialized: p.Serialized,
+		ParentID:   p.ParentID,
+		OnHand:     p.Stock,
+		Available:  p.Stock,
+
+		MinStock:        p.MinStock,
+		ReorderPoint:    p.ReorderPoint,
+		ReorderQuantity: p.ReorderQuantity,
+		LowStock:        isLowStock(p),
+	}
+	if p.BaseUnit != nil {
+		response.BaseUnit = p.BaseUnit.Code
+	}
+	if p.SKU != nil {
+		response.SKU = *p.SKU
+	}
+	if p.DeletedAt.Valid {
+		response.DeletedAt = &p.DeletedAt.Time
+	}
+	for _, b := range p.Barcodes {
+		response.Barcodes = append(response.Barcodes, toProductBarcodeResponse(b))
 	}
+	for _, a := range p.Attachments {
+		if a.Kind == "image" {
+			response.Images = append(response.Images, toProductAttachmentResponse(a))
+		} else {
+			response.Documents = append(response.Documents, toProductAttachmentResponse(a))
+		}
+	}
+	if len(p.Attributes) > 0 {
+		response.Attributes = map[string]string{}
+		for _, a := range p.Attributes {
+			response.Attributes[a.Name] = a.Value
+		}
+	}
+	for _, u := range p.Units {
+		response.Units = append(response.Units, web.ProductUnitResponse{
+			Unit:   u.Unit.Code,
+			Name:   u.Unit.Name,
+			Factor: u.Factor,
+		})
+	}
+	return response
+}
+
+// lowStockThreshold adalah nilai terbesar antara stok minimum dan reorder point.
+// Produk tanpa keduanya (0) tidak pernah dianggap stok menipis.
+func lowStockThreshold(p domain.Product) int {
+	return max(p.MinStock, p.ReorderPoint)
+}
+
+func isLowStock(p domain.Product) bool {
+	return p.Stock < lowStockThreshold(p)
 }
 
 func toProductResponses(products []domain.Product) []web.ProductResponse {
@@ -128,3 +616,48 @@ func toProductResponses(products []domain.Product) []web.ProductResponse {
 	}
 	return responses
 }
+
+// normalizeAttributes merapikan nama atribut (huruf kecil, tanpa spasi di tepi) dan nilainya
+func normalizeAttributes(attributes map[string]string) map[string]string {
+	normalized := map[string]string{}
+	for name, value := range attributes {
+		normalized[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+	return normalized
+}
+
+func sameAttributes(attributes map[string]string, existing []domain.ProductAttribute) bool {
+	if len(attributes) != len(existing) {
+		return false
+	}
+	for _, a := range existing {
+		if value, ok := attributes[a.Name]; !ok || !strings.EqualFold(value, a.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// attributeValues menggabungkan nilai atribut berurutan nama atribut, misalnya "Red, M"
+func attributeValues(attributes map[string]string) string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, attributes[name])
+	}
+	return strings.Join(values, ", ")
+}
+
+// optionalSKU mengubah SKU kosong menjadi nil (kolom sku nullable agar tetap unik)
+func optionalSKU(sku string) *string {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil
+	}
+	return &sku
+}
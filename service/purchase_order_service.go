@@ -0,0 +1,352 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderService interface {
+	FindAll(filters map[string]interface{}) ([]web.PurchaseOrderResponse, error)
+	FindById(id int) (web.PurchaseOrderResponse, error)
+	Create(adminID int, req web.PurchaseOrderCreateOrUpdateRequest) (web.PurchaseOrderResponse, error)
+	Update(id int, req web.PurchaseOrderCreateOrUpdateRequest) (web.PurchaseOrderResponse, error)
+	Order(id int) (web.PurchaseOrderResponse, error)
+	Cancel(id int) (web.PurchaseOrderResponse, error)
+	Receive(id int, userID int, isAdmin bool, req web.PurchaseOrderReceiveRequest) (web.PurchaseOrderResponse, error)
+}
+
+type purchaseOrderService struct {
+	Repo            repository.PurchaseOrderRepository
+	RepoMovement    repository.StockMovementRepository
+	RepoProduct     repository.ProductRepository
+	RepoWarehouse   repository.WarehouseRepository
+	MovementService StockMovementService
+	DB              *gorm.DB
+	Validate        *validator.Validate
+}
+
+func NewPurchaseOrderService(
+	repo repository.PurchaseOrderRepository,
+	repoMovement repository.StockMovementRepository,
+	repoProduct repository.ProductRepository,
+	repoWarehouse repository.WarehouseRepository,
+	movementService StockMovementService,
+	db *gorm.DB,
+	validate *validator.Validate,
+) PurchaseOrderService {
+	return &purchaseOrderService{
+		Repo:            repo,
+		RepoMovement:    repoMovement,
+		RepoProduct:     repoProduct,
+		RepoWarehouse:   repoWarehouse,
+		MovementService: movementService,
+		DB:              db,
+		Validate:        validate,
+	}
+}
+
+func (s *purchaseOrderService) FindAll(filters map[string]interface{}) ([]web.PurchaseOrderResponse, error) {
+	orders, err := s.Repo.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []web.PurchaseOrderResponse
+	for _, o := range orders {
+		responses = append(responses, toPurchaseOrderResponse(o))
+	}
+	return responses, nil
+}
+
+func (s *purchaseOrderService) FindById(id int) (web.PurchaseOrderResponse, error) {
+	o, err := s.Repo.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.PurchaseOrderResponse{}, errors.New("purchase order not found")
+		}
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	receipts, err := s.RepoMovement.FindByReference(purchaseOrderReference(o.ID))
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	response := toPurchaseOrderResponse(o)
+	for _, m := range receipts {
+		response.Receipts = append(response.Receipts, toStockMovementResponse(m))
+	}
+	return response, nil
+}
+
+func (s *purchaseOrderService) Create(adminID int, req web.PurchaseOrderCreateOrUpdateRequest) (web.PurchaseOrderResponse, error) {
+	order, err := s.buildOrder(req)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	order.Status = "draft"
+	order.CreatedBy = adminID
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err = s.Repo.Save(order, tx)
+		return err
+	})
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return s.FindById(order.ID)
+}
+
+// Update mengganti header dan seluruh baris PO. Hanya PO berstatus draft yang bisa diubah.
+func (s *purchaseOrderService) Update(id int, req web.PurchaseOrderCreateOrUpdateRequest) (web.PurchaseOrderResponse, error) {
+	order, err := s.buildOrder(req)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	order.ID = id
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		existing, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+		if existing.Status != "draft" {
+			return errors.New("purchase order is not a draft")
+		}
+		if err := s.Repo.Update(order, tx); err != nil {
+			return err
+		}
+		return s.Repo.ReplaceLines(id, order.Lines, tx)
+	})
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// Order mengirim PO ke supplier (draft -> ordered) sehingga barang bisa mulai diterima
+func (s *purchaseOrderService) Order(id int) (web.PurchaseOrderResponse, error) {
+	return s.changeStatus(id, "ordered", "draft")
+}
+
+// Cancel membatalkan PO yang belum menerima barang sama sekali
+func (s *purchaseOrderService) Cancel(id int) (web.PurchaseOrderResponse, error) {
+	return s.changeStatus(id, "cancelled", "draft", "ordered")
+}
+
+func (s *purchaseOrderService) changeStatus(id int, status string, allowedFrom ...string) (web.PurchaseOrderResponse, error) {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, from := range allowedFrom {
+			if order.Status == from {
+				return s.Repo.UpdateStatus(id, status, tx)
+			}
+		}
+		return errors.New("purchase order status does not allow this action")
+	})
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// Receive mencatat barang yang diterima terhadap baris PO. Setiap baris memposting movement
+// "in" lewat StockMovementService dalam satu transaksi, lalu status PO diperbarui menjadi
+// partially_received atau received. Penerimaan melebihi sisa pesanan ditolak kecuali admin
+// mengizinkannya lewat allow_over_receipt.
+func (s *purchaseOrderService) Receive(id int, userID int, isAdmin bool, req web.PurchaseOrderReceiveRequest) (web.PurchaseOrderResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.PurchaseOrderResponse{}, errors.New("validation failed")
+	}
+	if req.AllowOverReceipt && !isAdmin {
+		return web.PurchaseOrderResponse{}, errors.New("only admin can allow over receipt")
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+		if order.Status != "ordered" && order.Status != "partially_received" {
+			return errors.New("purchase order is not open for receiving")
+		}
+
+		warehouseID := order.WarehouseID
+		if req.WarehouseID != 0 {
+			warehouseID = req.WarehouseID
+		}
+
+		lines := map[int]*domain.PurchaseOrderLine{}
+		for i := range order.Lines {
+			lines[order.Lines[i].ID] = &order.Lines[i]
+		}
+
+		for _, receipt := range req.Lines {
+			if _, ok := lines[receipt.LineID]; !ok {
+				return errors.New("line is not part of this purchase order")
+			}
+		}
+
+		// Posting diurutkan berdasarkan product_id agar urutan penguncian baris produk konsisten
+		receipts := append([]web.PurchaseOrderReceiveLineRequest(nil), req.Lines...)
+		sort.SliceStable(receipts, func(a, b int) bool {
+			return lines[receipts[a].LineID].ProductID < lines[receipts[b].LineID].ProductID
+		})
+
+		for _, receipt := range receipts {
+			line := lines[receipt.LineID]
+			if line.ReceivedQuantity+receipt.Quantity > line.OrderedQuantity && !req.AllowOverReceipt {
+				return errors.New("quantity exceeds remaining order quantity")
+			}
+
+			opts := MovementOptions{LotNumber: receipt.LotNumber, SerialNumbers: receipt.SerialNumbers}
+			// Harga 0 pada baris PO berarti belum diisi, movement memakai average cost produk
+			if receipt.UnitCost != nil {
+				opts.UnitCost = receipt.UnitCost
+			} else if line.UnitCost > 0 {
+				opts.UnitCost = &line.UnitCost
+			}
+			if receipt.ExpiryDate != "" {
+				expiryDate, err := time.Parse("2006-01-02", receipt.ExpiryDate)
+				if err != nil {
+					return errors.New("validation failed")
+				}
+				opts.ExpiryDate = &expiryDate
+			}
+
+			note := req.Note
+			if note == "" {
+				note = fmt.Sprintf("Penerimaan PO #%d dari %s", order.ID, order.Supplier)
+			}
+
+			_, err := s.MovementService.Post(tx, domain.StockMovement{
+				ProductID:   line.ProductID,
+				UserID:      userID,
+				WarehouseID: warehouseID,
+				Type:        "in",
+				Quantity:    receipt.Quantity,
+				Note:        note,
+				Reference:   purchaseOrderReference(order.ID),
+			}, opts)
+			if err != nil {
+				return err
+			}
+
+			line.ReceivedQuantity += receipt.Quantity
+			if err := s.Repo.UpdateLineReceived(line.ID, line.ReceivedQuantity, tx); err != nil {
+				return err
+			}
+		}
+
+		status := "received"
+		for _, line := range order.Lines {
+			if line.ReceivedQuantity < line.OrderedQuantity {
+				status = "partially_received"
+				break
+			}
+		}
+		return s.Repo.UpdateStatus(order.ID, status, tx)
+	})
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+func (s *purchaseOrderService) lockOrder(id int, tx *gorm.DB) (domain.PurchaseOrder, error) {
+	order, err := s.Repo.FindByIdForUpdate(id, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return order, errors.New("purchase order not found")
+	}
+	return order, err
+}
+
+// buildOrder memvalidasi request dan menyusun PO beserta barisnya
+func (s *purchaseOrderService) buildOrder(req web.PurchaseOrderCreateOrUpdateRequest) (domain.PurchaseOrder, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return domain.PurchaseOrder{}, errors.New("validation failed")
+	}
+	if _, err := s.RepoWarehouse.FindById(req.WarehouseID); err != nil {
+		return domain.PurchaseOrder{}, errors.New("warehouse not found")
+	}
+
+	order := domain.PurchaseOrder{
+		Supplier:    req.Supplier,
+		WarehouseID: req.WarehouseID,
+		Note:        req.Note,
+	}
+	if req.ExpectedDate != "" {
+		expectedDate, err := time.Parse("2006-01-02", req.ExpectedDate)
+		if err != nil {
+			return domain.PurchaseOrder{}, errors.New("validation failed")
+		}
+		order.ExpectedDate = &expectedDate
+	}
+
+	seen := map[int]bool{}
+	for _, line := range req.Lines {
+		if seen[line.ProductID] {
+			return domain.PurchaseOrder{}, errors.New("duplicate product in purchase order")
+		}
+		seen[line.ProductID] = true
+
+		if _, err := s.RepoProduct.FindById(line.ProductID); err != nil {
+			return domain.PurchaseOrder{}, errors.New("product not found")
+		}
+		order.Lines = append(order.Lines, domain.PurchaseOrderLine{
+			ProductID:       line.ProductID,
+			OrderedQuantity: line.Quantity,
+			UnitCost:        line.UnitCost,
+			Note:            line.Note,
+		})
+	}
+	return order, nil
+}
+
+func purchaseOrderReference(id int) string {
+	return fmt.Sprintf("purchase-order:%d", id)
+}
+
+func toPurchaseOrderResponse(o domain.PurchaseOrder) web.PurchaseOrderResponse {
+	response := web.PurchaseOrderResponse{
+		ID:           o.ID,
+		Supplier:     o.Supplier,
+		WarehouseID:  o.WarehouseID,
+		Status:       o.Status,
+		ExpectedDate: formatDate(o.ExpectedDate),
+		Note:         o.Note,
+		CreatedBy:    o.CreatedBy,
+		CreatedAt:    o.CreatedAt,
+		UpdatedAt:    o.UpdatedAt,
+		Lines:        []web.PurchaseOrderLineResponse{},
+	}
+	for _, line := range o.Lines {
+		response.Lines = append(response.Lines, web.PurchaseOrderLineResponse{
+			ID:                line.ID,
+			ProductID:         line.ProductID,
+			Product:           line.Product.Name,
+			OrderedQuantity:   line.OrderedQuantity,
+			ReceivedQuantity:  line.ReceivedQuantity,
+			RemainingQuantity: max(line.OrderedQuantity-line.ReceivedQuantity, 0),
+			UnitCost:          line.UnitCost,
+			Note:              line.Note,
+		})
+	}
+	return response
+}
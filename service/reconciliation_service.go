@@ -0,0 +1,240 @@
+package service
+
+import (
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReconciliationService interface {
+	Check() (web.ReconciliationResponse, error)
+	Repair(userID int) (web.ReconciliationResponse, error)
+}
+
+type reconciliationService struct {
+	RepoMovement    repository.StockMovementRepository
+	RepoProduct     repository.ProductRepository
+	RepoStock       repository.ProductStockRepository
+	RepoWarehouse   repository.WarehouseRepository
+	MovementService StockMovementService
+	DB              *gorm.DB
+}
+
+func NewReconciliationService(
+	repoMovement repository.StockMovementRepository,
+	repoProduct repository.ProductRepository,
+	repoStock repository.ProductStockRepository,
+	repoWarehouse repository.WarehouseRepository,
+	movementService StockMovementService,
+	db *gorm.DB,
+) ReconciliationService {
+	return &reconciliationService{
+		RepoMovement:    repoMovement,
+		RepoProduct:     repoProduct,
+		RepoStock:       repoStock,
+		RepoWarehouse:   repoWarehouse,
+		MovementService: movementService,
+		DB:              db,
+	}
+}
+
+// Check menghitung ulang saldo setiap produk per gudang dari seluruh stock movement dan
+// melaporkan produk yang stok tersimpannya berbeda. Tidak ada data yang diubah.
+func (s *reconciliationService) Check() (web.ReconciliationResponse, error) {
+	products, err := s.RepoProduct.FindAll(nil)
+	if err != nil {
+		return web.ReconciliationResponse{}, err
+	}
+	stocks, err := s.RepoStock.FindAll()
+	if err != nil {
+		return web.ReconciliationResponse{}, err
+	}
+	now := time.Now()
+	ledger, err := s.RepoMovement.SumBalances(nil, now.Add(time.Second), 0)
+	if err != nil {
+		return web.ReconciliationResponse{}, err
+	}
+	warehouseNames, err := s.warehouseNames()
+	if err != nil {
+		return web.ReconciliationResponse{}, err
+	}
+
+	response := web.ReconciliationResponse{
+		CheckedAt:       now,
+		ProductsChecked: len(products),
+		Drifts:          []web.ProductDriftResponse{},
+	}
+	for _, p := range products {
+		var productStocks []domain.ProductStock
+		for _, st := range stocks {
+			if st.ProductID == p.ID {
+				productStocks = append(productStocks, st)
+			}
+		}
+		var productLedger []repository.WarehouseBalance
+		for _, b := range ledger {
+			if b.ProductID == p.ID {
+				productLedger = append(productLedger, b)
+			}
+		}
+
+		if drift, ok := compareStock(p, productStocks, productLedger, warehouseNames); ok {
+			response.Drifts = append(response.Drifts, drift)
+		}
+	}
+	return response, nil
+}
+
+// Repair memperbaiki selisih yang ditemukan Check. Stok tersimpan dianggap benar (selisih
+// berasal dari perubahan stok yang tidak tercatat sebagai movement), jadi untuk setiap
+// selisih per gudang dicatat movement adjust dengan Reference "reconciliation:<waktu>"
+// tanpa mengubah stok lagi; lot dan cost layer tetap disesuaikan lewat
+// StockMovementService.RecordDrift. Product.Stock yang berbeda dari jumlah saldo gudang diperbaiki
+// menjadi jumlah saldo gudang. Setiap produk diperbaiki dalam transaksinya sendiri
+// dengan baris produk dikunci, sehingga aman dijalankan saat ada movement lain.
+func (s *reconciliationService) Repair(userID int) (web.ReconciliationResponse, error) {
+	checked, err := s.Check()
+	if err != nil {
+		return web.ReconciliationResponse{}, err
+	}
+	warehouseNames, err := s.warehouseNames()
+	if err != nil {
+		return web.ReconciliationResponse{}, err
+	}
+
+	response := web.ReconciliationResponse{
+		CheckedAt:       checked.CheckedAt,
+		ProductsChecked: checked.ProductsChecked,
+		Repaired:        true,
+		Reference:       "reconciliation:" + checked.CheckedAt.Format("20060102150405"),
+		Drifts:          []web.ProductDriftResponse{},
+	}
+	for _, found := range checked.Drifts {
+		var repaired web.ProductDriftResponse
+		var drifted bool
+		err := s.DB.Transaction(func(tx *gorm.DB) error {
+			product, err := s.RepoProduct.FindByIdForUpdate(found.ProductID, tx)
+			if err != nil {
+				return err
+			}
+
+			// Hitung ulang setelah produk dikunci, movement baru tidak bisa masuk di tengah perbaikan
+			stocks, err := s.RepoStock.FindByProductIdForUpdate(product.ID, tx)
+			if err != nil {
+				return err
+			}
+			ledger, err := s.RepoMovement.SumBalances(nil, time.Now().Add(time.Second), product.ID)
+			if err != nil {
+				return err
+			}
+			repaired, drifted = compareStock(product, stocks, ledger, warehouseNames)
+			if !drifted {
+				return nil
+			}
+
+			for i, w := range repaired.Warehouses {
+				if w.Difference == 0 {
+					continue
+				}
+				saved, err := s.MovementService.RecordDrift(tx, domain.StockMovement{
+					ProductID:   product.ID,
+					UserID:      userID,
+					WarehouseID: w.WarehouseID,
+					Type:        "adjust",
+					Quantity:    w.Difference,
+					Note:        fmt.Sprintf("Koreksi selisih stok hasil rekonsiliasi (tersimpan %d, movement %d)", w.StoredStock, w.ExpectedStock),
+					Reference:   response.Reference,
+				})
+				if err != nil {
+					return err
+				}
+				repaired.Warehouses[i].AdjustmentMovementID = &saved.ID
+			}
+
+			if product.Stock != repaired.WarehouseTotal {
+				return s.RepoProduct.UpdateStock(product.ID, repaired.WarehouseTotal, tx)
+			}
+			return nil
+		})
+		if err != nil {
+			return web.ReconciliationResponse{}, err
+		}
+		if drifted {
+			response.Drifts = append(response.Drifts, repaired)
+		}
+	}
+	return response, nil
+}
+
+func (s *reconciliationService) warehouseNames() (map[int]string, error) {
+	warehouses, err := s.RepoWarehouse.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	names := map[int]string{}
+	for _, w := range warehouses {
+		names[w.ID] = w.Name
+	}
+	return names, nil
+}
+
+// compareStock membandingkan stok tersimpan satu produk dengan saldo movement-nya.
+// ok bernilai false jika tidak ada selisih sama sekali.
+func compareStock(p domain.Product, stocks []domain.ProductStock, ledger []repository.WarehouseBalance, warehouseNames map[int]string) (web.ProductDriftResponse, bool) {
+	stored := map[int]int{}
+	expected := map[int]int{}
+	for _, st := range stocks {
+		stored[st.WarehouseID] += st.Stock
+	}
+	for _, b := range ledger {
+		expected[b.WarehouseID] += b.Quantity
+	}
+
+	drift := web.ProductDriftResponse{
+		ProductID:   p.ID,
+		Name:        p.Name,
+		StoredStock: p.Stock,
+		Warehouses:  []web.WarehouseDriftResponse{},
+	}
+	warehouseDrift := false
+	for _, warehouseID := range unionKeys(stored, expected) {
+		drift.WarehouseTotal += stored[warehouseID]
+		drift.ExpectedStock += expected[warehouseID]
+
+		difference := stored[warehouseID] - expected[warehouseID]
+		if difference == 0 {
+			continue
+		}
+		warehouseDrift = true
+		drift.Warehouses = append(drift.Warehouses, web.WarehouseDriftResponse{
+			WarehouseID:   warehouseID,
+			Warehouse:     warehouseNames[warehouseID],
+			StoredStock:   stored[warehouseID],
+			ExpectedStock: expected[warehouseID],
+			Difference:    difference,
+		})
+	}
+	drift.Difference = drift.StoredStock - drift.ExpectedStock
+
+	return drift, warehouseDrift || drift.StoredStock != drift.WarehouseTotal
+}
+
+func unionKeys(a, b map[int]int) []int {
+	seen := map[int]bool{}
+	var keys []int
+	for _, m := range []map[int]int{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ReplenishmentService interface {
+	Suggest(query web.ReplenishmentQuery) ([]web.ReplenishmentSuggestionResponse, error)
+}
+
+type replenishmentService struct {
+	RepoProduct       repository.ProductRepository
+	RepoMovement      repository.StockMovementRepository
+	RepoReservation   repository.ReservationRepository
+	RepoPurchaseOrder repository.PurchaseOrderRepository
+	RepoSupplier      repository.SupplierRepository
+	Validate          *validator.Validate
+}
+
+func NewReplenishmentService(
+	repoProduct repository.ProductRepository,
+	repoMovement repository.StockMovementRepository,
+	repoReservation repository.ReservationRepository,
+	repoPurchaseOrder repository.PurchaseOrderRepository,
+	repoSupplier repository.SupplierRepository,
+	validate *validator.Validate,
+) ReplenishmentService {
+	return &replenishmentService{
+		RepoProduct:       repoProduct,
+		RepoMovement:      repoMovement,
+		RepoReservation:   repoReservation,
+		RepoPurchaseOrder: repoPurchaseOrder,
+		RepoSupplier:      repoSupplier,
+		Validate:          validate,
+	}
+}
+
+// Suggest menghitung saran pembelian per produk:
+//   - pemakaian rata-rata harian = total movement keluar selama WindowDays / WindowDays
+//   - safety stock = nilai terbesar antara min_stock dan pemakaian selama SafetyDays
+//   - reorder level = nilai terbesar antara reorder_point dan pemakaian selama lead time + safety stock
+//   - jika stok available + yang sedang dipesan (PO terbuka) di bawah reorder level, disarankan
+//     memesan kekurangannya, minimal sebesar reorder_quantity produk
+//
+// Lead time diambil dari supplier preferred, jika tidak ada memakai LeadTimeDays dari query.
+func (s *replenishmentService) Suggest(query web.ReplenishmentQuery) ([]web.ReplenishmentSuggestionResponse, error) {
+	if err := s.Validate.Struct(query); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	filters := map[string]interface{}{}
+	if query.SupplierID != 0 {
+		filters["supplier_id"] = query.SupplierID
+	}
+	products, err := s.RepoProduct.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	consumed, err := s.RepoMovement.SumOutboundByProduct(now.AddDate(0, 0, -query.WindowDays))
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := s.RepoReservation.SumActiveByProduct(now)
+	if err != nil {
+		return nil, err
+	}
+	onOrder, err := s.RepoPurchaseOrder.SumOpenByProduct()
+	if err != nil {
+		return nil, err
+	}
+	preferred, err := s.RepoSupplier.FindPreferred()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []web.ReplenishmentSuggestionResponse{}
+	for _, p := range products {
+		avg := float64(consumed[p.ID]) / float64(query.WindowDays)
+
+		response := web.ReplenishmentSuggestionResponse{
+			ProductID:           p.ID,
+			Name:                p.Name,
+			OnHand:              p.Stock,
+			Reserved:            reserved[p.ID],
+			Available:           p.Stock - reserved[p.ID],
+			OnOrder:             onOrder[p.ID],
+			Consumed:            consumed[p.ID],
+			WindowDays:          query.WindowDays,
+			AvgDailyConsumption: math.Round(avg*100) / 100,
+			LeadTimeDays:        query.LeadTimeDays,
+		}
+
+		if link, ok := preferred[p.ID]; ok {
+			response.SupplierID = &link.SupplierID
+			response.Supplier = link.Supplier.Name
+			response.SupplierSKU = link.SupplierSKU
+			if link.Supplier.LeadTimeDays > 0 {
+				response.LeadTimeDays = link.Supplier.LeadTimeDays
+			}
+		}
+
+		if avg > 0 {
+			cover := float64(max(response.Available, 0)) / avg
+			rounded := math.Round(cover*10) / 10
+			response.DaysOfCover = &rounded
+			response.StockoutDate = now.AddDate(0, 0, int(cover)).Format("2006-01-02")
+		}
+
+		response.SafetyStock = max(p.MinStock, int(math.Ceil(avg*float64(query.SafetyDays))))
+		response.ReorderLevel = max(p.ReorderPoint, int(math.Ceil(avg*float64(response.LeadTimeDays)))+response.SafetyStock)
+
+		position := response.Available + response.OnOrder
+		if position < response.ReorderLevel {
+			response.SuggestedQuantity = max(response.ReorderLevel-position, p.ReorderQuantity)
+		}
+
+		if query.OnlyNeeded && response.SuggestedQuantity == 0 {
+			continue
+		}
+		responses = append(responses, response)
+	}
+
+	// Produk yang paling cepat habis ditampilkan paling atas, produk tanpa pemakaian paling bawah
+	sort.SliceStable(responses, func(i, j int) bool {
+		a, b := responses[i].DaysOfCover, responses[j].DaysOfCover
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+	return responses, nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type ReservationService interface {
+	FindAll(filters map[string]interface{}) ([]web.ReservationResponse, error)
+	FindById(id int) (web.ReservationResponse, error)
+	Create(userID int, req web.ReservationCreateRequest) (web.ReservationResponse, error)
+	Release(id int) (web.ReservationResponse, error)
+	ReleaseExpired() (int64, error)
+	Hold(tx *gorm.DB, reservation domain.Reservation, allowPartial bool) (domain.Reservation, error)
+	ReleaseHold(tx *gorm.DB, id int) error
+}
+
+type reservationService struct {
+	Repo          repository.ReservationRepository
+	RepoProduct   repository.ProductRepository
+	RepoStock     repository.ProductStockRepository
+	RepoWarehouse repository.WarehouseRepository
+	DB            *gorm.DB
+	Validate      *validator.Validate
+}
+
+func NewReservationService(
+	repo repository.ReservationRepository,
+	repoProduct repository.ProductRepository,
+	repoStock repository.ProductStockRepository,
+	repoWarehouse repository.WarehouseRepository,
+	db *gorm.DB,
+	validate *validator.Validate,
+) ReservationService {
+	return &reservationService{
+		Repo:          repo,
+		RepoProduct:   repoProduct,
+		RepoStock:     repoStock,
+		RepoWarehouse: repoWarehouse,
+		DB:            db,
+		Validate:      validate,
+	}
+}
+
+func (s *reservationService) FindAll(filters map[string]interface{}) ([]web.ReservationResponse, error) {
+	reservations, err := s.Repo.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var responses []web.ReservationResponse
+	for _, r := range reservations {
+		responses = append(responses, toReservationResponse(r, now))
+	}
+	return responses, nil
+}
+
+func (s *reservationService) FindById(id int) (web.ReservationResponse, error) {
+	r, err := s.Repo.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.ReservationResponse{}, errors.New("reservation not found")
+		}
+		return web.ReservationResponse{}, err
+	}
+	return toReservationResponse(r, time.Now()), nil
+}
+
+// Create menahan stok jika jumlah yang tersedia (on hand dikurangi reservasi aktif lain)
+// mencukupi. Baris produk dikunci agar tidak balapan dengan movement atau reservasi lain.
+func (s *reservationService) Create(userID int, req web.ReservationCreateRequest) (web.ReservationResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.ReservationResponse{}, errors.New("validation failed")
+	}
+
+	now := time.Now()
+	reservation := domain.Reservation{
+		ProductID:   req.ProductID,
+		WarehouseID: req.WarehouseID,
+		Quantity:    req.Quantity,
+		Owner:       req.Owner,
+		Note:        req.Note,
+		CreatedBy:   userID,
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return web.ReservationResponse{}, errors.New("validation failed")
+		}
+		if !expiresAt.After(now) {
+			return web.ReservationResponse{}, errors.New("expires_at must be in the future")
+		}
+		reservation.ExpiresAt = &expiresAt
+	}
+
+	if _, err := s.RepoWarehouse.FindById(req.WarehouseID); err != nil {
+		return web.ReservationResponse{}, errors.New("warehouse not found")
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		reservation, err = s.Hold(tx, reservation, false)
+		return err
+	})
+	if err != nil {
+		return web.ReservationResponse{}, err
+	}
+
+	return s.FindById(reservation.ID)
+}
+
+// Hold menyimpan reservasi di dalam transaksi tx milik pemanggil (misalnya sales order).
+// Baris produk dikunci agar tidak balapan dengan movement atau reservasi lain. Dengan
+// allowPartial, jumlah yang ditahan dipotong ke stok yang tersedia; jika tidak ada yang
+// bisa ditahan, reservasi kosong (ID 0) dikembalikan tanpa error.
+func (s *reservationService) Hold(tx *gorm.DB, reservation domain.Reservation, allowPartial bool) (domain.Reservation, error) {
+	product, err := s.RepoProduct.FindByIdForUpdate(reservation.ProductID, tx)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	stock, err := s.RepoStock.FindOrCreateForUpdate(product.ID, reservation.WarehouseID, tx)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+	reserved, err := s.Repo.SumActive(product.ID, reservation.WarehouseID, time.Now(), tx)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	available := stock.Stock - reserved
+	if reservation.Quantity > available {
+		if !allowPartial {
+			return domain.Reservation{}, errors.New("available stock not enough")
+		}
+		reservation.Quantity = max(available, 0)
+	}
+	if reservation.Quantity == 0 {
+		return domain.Reservation{}, nil
+	}
+
+	reservation.ReservedQuantity = reservation.Quantity
+	reservation.Status = "active"
+	return s.Repo.Save(reservation, tx)
+}
+
+// ReleaseHold melepas reservasi di dalam transaksi tx. Reservasi yang sudah tidak aktif
+// dibiarkan apa adanya.
+func (s *reservationService) ReleaseHold(tx *gorm.DB, id int) error {
+	r, err := s.Repo.FindByIdForUpdate(id, tx)
+	if err != nil {
+		return err
+	}
+	if !isReservationActive(r, time.Now()) {
+		return nil
+	}
+	return s.Repo.UpdateQuantity(r.ID, r.Quantity, "released", tx)
+}
+
+func (s *reservationService) Release(id int) (web.ReservationResponse, error) {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		r, err := s.Repo.FindByIdForUpdate(id, tx)
+		if err != nil {
+			return err
+		}
+		if !isReservationActive(r, time.Now()) {
+			return errors.New("reservation is not active")
+		}
+		return s.Repo.UpdateQuantity(r.ID, r.Quantity, "released", tx)
+	})
+	if err != nil {
+		return web.ReservationResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// ReleaseExpired menandai reservasi yang lewat expires_at sebagai expired. Perhitungan
+// available sudah mengabaikan reservasi tersebut, jadi ini hanya merapikan statusnya.
+func (s *reservationService) ReleaseExpired() (int64, error) {
+	return s.Repo.ReleaseExpired(time.Now())
+}
+
+func isReservationActive(r domain.Reservation, now time.Time) bool {
+	return r.Status == "active" && (r.ExpiresAt == nil || r.ExpiresAt.After(now))
+}
+
+func toReservationResponse(r domain.Reservation, now time.Time) web.ReservationResponse {
+	status := r.Status
+	if status == "active" && !isReservationActive(r, now) {
+		status = "expired"
+	}
+
+	return web.ReservationResponse{
+		ID:               r.ID,
+		ProductID:        r.ProductID,
+		WarehouseID:      r.WarehouseID,
+		Quantity:         r.Quantity,
+		ReservedQuantity: r.ReservedQuantity,
+		Owner:            r.Owner,
+		Note:             r.Note,
+		Status:           status,
+		ExpiresAt:        r.ExpiresAt,
+		CreatedBy:        r.CreatedBy,
+		CreatedAt:        r.CreatedAt,
+	}
+}
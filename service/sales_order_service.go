@@ -0,0 +1,438 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type SalesOrderService interface {
+	FindAll(filters map[string]interface{}) ([]web.SalesOrderResponse, error)
+	FindById(id int) (web.SalesOrderResponse, error)
+	Create(userID int, req web.SalesOrderCreateOrUpdateRequest) (web.SalesOrderResponse, error)
+	Update(id int, req web.SalesOrderCreateOrUpdateRequest) (web.SalesOrderResponse, error)
+	Confirm(id int, userID int) (web.SalesOrderResponse, error)
+	Pick(id int) (web.SalesOrderResponse, error)
+	Ship(id int, userID int, req web.SalesOrderShipRequest) (web.SalesOrderResponse, error)
+	Cancel(id int) (web.SalesOrderResponse, error)
+}
+
+type salesOrderService struct {
+	Repo               repository.SalesOrderRepository
+	RepoMovement       repository.StockMovementRepository
+	RepoProduct        repository.ProductRepository
+	RepoWarehouse      repository.WarehouseRepository
+	RepoReservation    repository.ReservationRepository
+	MovementService    StockMovementService
+	ReservationService ReservationService
+	DB                 *gorm.DB
+	Validate           *validator.Validate
+}
+
+func NewSalesOrderService(
+	repo repository.SalesOrderRepository,
+	repoMovement repository.StockMovementRepository,
+	repoProduct repository.ProductRepository,
+	repoWarehouse repository.WarehouseRepository,
+	repoReservation repository.ReservationRepository,
+	movementService StockMovementService,
+	reservationService ReservationService,
+	db *gorm.DB,
+	validate *validator.Validate,
+) SalesOrderService {
+	return &salesOrderService{
+		Repo:               repo,
+		RepoMovement:       repoMovement,
+		RepoProduct:        repoProduct,
+		RepoWarehouse:      repoWarehouse,
+		RepoReservation:    repoReservation,
+		MovementService:    movementService,
+		ReservationService: reservationService,
+		DB:                 db,
+		Validate:           validate,
+	}
+}
+
+func (s *salesOrderService) FindAll(filters map[string]interface{}) ([]web.SalesOrderResponse, error) {
+	orders, err := s.Repo.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var responses []web.SalesOrderResponse
+	for _, o := range orders {
+		responses = append(responses, toSalesOrderResponse(o, now))
+	}
+	return responses, nil
+}
+
+func (s *salesOrderService) FindById(id int) (web.SalesOrderResponse, error) {
+	o, err := s.Repo.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.SalesOrderResponse{}, errors.New("sales order not found")
+		}
+		return web.SalesOrderResponse{}, err
+	}
+
+	shipments, err := s.RepoMovement.FindByReference(salesOrderReference(o.ID))
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+
+	response := toSalesOrderResponse(o, time.Now())
+	for _, m := range shipments {
+		response.Shipments = append(response.Shipments, toStockMovementResponse(m))
+	}
+	return response, nil
+}
+
+func (s *salesOrderService) Create(userID int, req web.SalesOrderCreateOrUpdateRequest) (web.SalesOrderResponse, error) {
+	order, err := s.buildOrder(req)
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+	order.Status = "draft"
+	order.CreatedBy = userID
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err = s.Repo.Save(order, tx)
+		return err
+	})
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+
+	return s.FindById(order.ID)
+}
+
+// Update mengganti header dan seluruh baris order. Hanya order berstatus draft yang bisa diubah.
+func (s *salesOrderService) Update(id int, req web.SalesOrderCreateOrUpdateRequest) (web.SalesOrderResponse, error) {
+	order, err := s.buildOrder(req)
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+	order.ID = id
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		existing, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+		if existing.Status != "draft" {
+			return errors.New("sales order is not a draft")
+		}
+		if err := s.Repo.Update(order, tx); err != nil {
+			return err
+		}
+		return s.Repo.ReplaceLines(id, order.Lines, tx)
+	})
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// Confirm mengklaim stok untuk setiap baris dengan membuat reservasi sebesar stok yang
+// tersedia. Kekurangannya dicatat sebagai backorder dan diklaim lagi saat pengiriman berikutnya.
+func (s *salesOrderService) Confirm(id int, userID int) (web.SalesOrderResponse, error) {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+		if order.Status != "draft" {
+			return errors.New("sales order is not a draft")
+		}
+
+		for _, line := range linesByProduct(order.Lines) {
+			if err := s.claim(tx, order, line, userID); err != nil {
+				return err
+			}
+		}
+		return s.Repo.UpdateStatus(order.ID, "confirmed", tx)
+	})
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// Pick menandai barang order sudah diambil dari rak dan siap dikirim
+func (s *salesOrderService) Pick(id int) (web.SalesOrderResponse, error) {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+		if order.Status != "confirmed" {
+			return errors.New("sales order status does not allow this action")
+		}
+		return s.Repo.UpdateStatus(order.ID, "picked", tx)
+	})
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// Ship memposting movement "out" lewat StockMovementService untuk setiap baris yang dikirim,
+// memakai stok yang ditahan reservasi baris tersebut. Pengiriman boleh sebagian; order baru
+// berstatus shipped setelah semua baris terkirim penuh.
+func (s *salesOrderService) Ship(id int, userID int, req web.SalesOrderShipRequest) (web.SalesOrderResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.SalesOrderResponse{}, errors.New("validation failed")
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+		if order.Status != "confirmed" && order.Status != "picked" {
+			return errors.New("sales order is not open for shipping")
+		}
+
+		lines := map[int]*domain.SalesOrderLine{}
+		for i := range order.Lines {
+			lines[order.Lines[i].ID] = &order.Lines[i]
+		}
+		for _, shipment := range req.Lines {
+			if _, ok := lines[shipment.LineID]; !ok {
+				return errors.New("line is not part of this sales order")
+			}
+		}
+
+		// Posting diurutkan berdasarkan product_id agar urutan penguncian baris produk konsisten
+		shipments := append([]web.SalesOrderShipLineRequest(nil), req.Lines...)
+		sort.SliceStable(shipments, func(a, b int) bool {
+			return lines[shipments[a].LineID].ProductID < lines[shipments[b].LineID].ProductID
+		})
+
+		for _, shipment := range shipments {
+			line := lines[shipment.LineID]
+			if line.ShippedQuantity+shipment.Quantity > line.OrderedQuantity {
+				return errors.New("quantity exceeds remaining order quantity")
+			}
+			if err := s.shipLine(tx, order, line, shipment, userID, req.Note); err != nil {
+				return err
+			}
+		}
+
+		status := "shipped"
+		for _, line := range order.Lines {
+			if line.ShippedQuantity < line.OrderedQuantity {
+				status = order.Status
+				break
+			}
+		}
+		return s.Repo.UpdateStatus(order.ID, status, tx)
+	})
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// Cancel membatalkan order yang belum terkirim penuh dan melepas semua reservasinya.
+// Barang yang sudah terkirim tetap tercatat sebagai movement.
+func (s *salesOrderService) Cancel(id int) (web.SalesOrderResponse, error) {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockOrder(id, tx)
+		if err != nil {
+			return err
+		}
+		if order.Status == "shipped" || order.Status == "cancelled" {
+			return errors.New("sales order status does not allow this action")
+		}
+
+		for _, line := range order.Lines {
+			if line.ReservationID == nil {
+				continue
+			}
+			if err := s.ReservationService.ReleaseHold(tx, *line.ReservationID); err != nil {
+				return err
+			}
+		}
+		return s.Repo.UpdateStatus(order.ID, "cancelled", tx)
+	})
+	if err != nil {
+		return web.SalesOrderResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// shipLine memposting satu pengiriman. Jika jumlah kirim muat di reservasi baris, reservasi
+// tersebut dikonsumsi; jika tidak, reservasinya dilepas dan stok diambil dari yang tersedia.
+// Sisa baris yang belum terkirim kemudian diklaim ulang (backorder).
+func (s *salesOrderService) shipLine(tx *gorm.DB, order domain.SalesOrder, line *domain.SalesOrderLine, shipment web.SalesOrderShipLineRequest, userID int, note string) error {
+	opts := MovementOptions{LotNumber: shipment.LotNumber, SerialNumbers: shipment.SerialNumbers}
+
+	// Reclaim bernilai true jika reservasi baris habis atau dilepas sehingga sisa baris perlu diklaim ulang
+	reclaim := true
+	if line.ReservationID != nil {
+		reservation, err := s.RepoReservation.FindById(*line.ReservationID)
+		if err != nil {
+			return err
+		}
+		if isReservationActive(reservation, time.Now()) && shipment.Quantity <= reservation.Quantity {
+			opts.ReservationID = line.ReservationID
+			reclaim = shipment.Quantity == reservation.Quantity
+		} else if err := s.ReservationService.ReleaseHold(tx, reservation.ID); err != nil {
+			return err
+		}
+	}
+
+	if note == "" {
+		note = fmt.Sprintf("Pengiriman SO #%d untuk %s", order.ID, order.Customer)
+	}
+	_, err := s.MovementService.Post(tx, domain.StockMovement{
+		ProductID:   line.ProductID,
+		UserID:      userID,
+		WarehouseID: order.WarehouseID,
+		Type:        "out",
+		Quantity:    shipment.Quantity,
+		Note:        note,
+		Reference:   salesOrderReference(order.ID),
+	}, opts)
+	if err != nil {
+		return err
+	}
+
+	line.ShippedQuantity += shipment.Quantity
+	if reclaim {
+		line.ReservationID = nil
+		return s.claim(tx, order, line, userID)
+	}
+	return s.Repo.UpdateLine(*line, tx)
+}
+
+// claim menahan stok tersedia untuk sisa baris yang belum terkirim, lalu menyimpan barisnya
+func (s *salesOrderService) claim(tx *gorm.DB, order domain.SalesOrder, line *domain.SalesOrderLine, userID int) error {
+	remaining := line.OrderedQuantity - line.ShippedQuantity
+	if remaining > 0 {
+		reservation, err := s.ReservationService.Hold(tx, domain.Reservation{
+			ProductID:   line.ProductID,
+			WarehouseID: order.WarehouseID,
+			Quantity:    remaining,
+			Owner:       salesOrderReference(order.ID),
+			CreatedBy:   userID,
+		}, true)
+		if err != nil {
+			return err
+		}
+		if reservation.ID != 0 {
+			line.ReservationID = &reservation.ID
+		}
+	}
+	return s.Repo.UpdateLine(*line, tx)
+}
+
+func (s *salesOrderService) lockOrder(id int, tx *gorm.DB) (domain.SalesOrder, error) {
+	order, err := s.Repo.FindByIdForUpdate(id, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return order, errors.New("sales order not found")
+	}
+	return order, err
+}
+
+// buildOrder memvalidasi request dan menyusun order beserta barisnya
+func (s *salesOrderService) buildOrder(req web.SalesOrderCreateOrUpdateRequest) (domain.SalesOrder, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return domain.SalesOrder{}, errors.New("validation failed")
+	}
+	if _, err := s.RepoWarehouse.FindById(req.WarehouseID); err != nil {
+		return domain.SalesOrder{}, errors.New("warehouse not found")
+	}
+
+	order := domain.SalesOrder{
+		Customer:    req.Customer,
+		WarehouseID: req.WarehouseID,
+		Note:        req.Note,
+	}
+
+	seen := map[int]bool{}
+	for _, line := range req.Lines {
+		if seen[line.ProductID] {
+			return domain.SalesOrder{}, errors.New("duplicate product in sales order")
+		}
+		seen[line.ProductID] = true
+
+		if _, err := s.RepoProduct.FindById(line.ProductID); err != nil {
+			return domain.SalesOrder{}, errors.New("product not found")
+		}
+		order.Lines = append(order.Lines, domain.SalesOrderLine{
+			ProductID:       line.ProductID,
+			OrderedQuantity: line.Quantity,
+			Note:            line.Note,
+		})
+	}
+	return order, nil
+}
+
+// linesByProduct mengembalikan pointer ke baris order, diurutkan berdasarkan product_id
+func linesByProduct(lines []domain.SalesOrderLine) []*domain.SalesOrderLine {
+	sorted := make([]*domain.SalesOrderLine, len(lines))
+	for i := range lines {
+		sorted[i] = &lines[i]
+	}
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].ProductID < sorted[b].ProductID
+	})
+	return sorted
+}
+
+func salesOrderReference(id int) string {
+	return fmt.Sprintf("sales-order:%d", id)
+}
+
+func toSalesOrderResponse(o domain.SalesOrder, now time.Time) web.SalesOrderResponse {
+	response := web.SalesOrderResponse{
+		ID:          o.ID,
+		Customer:    o.Customer,
+		WarehouseID: o.WarehouseID,
+		Status:      o.Status,
+		Note:        o.Note,
+		CreatedBy:   o.CreatedBy,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+		Lines:       []web.SalesOrderLineResponse{},
+	}
+	for _, line := range o.Lines {
+		lineResponse := web.SalesOrderLineResponse{
+			ID:              line.ID,
+			ProductID:       line.ProductID,
+			Product:         line.Product.Name,
+			OrderedQuantity: line.OrderedQuantity,
+			ShippedQuantity: line.ShippedQuantity,
+			ReservationID:   line.ReservationID,
+			Note:            line.Note,
+		}
+		if line.Reservation != nil && isReservationActive(*line.Reservation, now) {
+			lineResponse.ReservedQuantity = line.Reservation.Quantity
+		}
+
+		// Backorder hanya berlaku untuk order yang sudah dikonfirmasi dan belum selesai
+		if o.Status == "confirmed" || o.Status == "picked" {
+			lineResponse.BackorderQuantity = max(line.OrderedQuantity-line.ShippedQuantity-lineResponse.ReservedQuantity, 0)
+			if lineResponse.BackorderQuantity > 0 {
+				response.Backordered = true
+			}
+		}
+		response.Lines = append(response.Lines, lineResponse)
+	}
+	return response
+}
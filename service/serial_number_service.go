@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+
+	"gorm.io/gorm"
+)
+
+type SerialNumberService interface {
+	FindBySerial(serial string) (web.SerialNumberResponse, error)
+}
+
+type serialNumberService struct {
+	RepoSerial   repository.SerialNumberRepository
+	RepoMovement repository.StockMovementRepository
+}
+
+func NewSerialNumberService(repoSerial repository.SerialNumberRepository, repoMovement repository.StockMovementRepository) SerialNumberService {
+	return &serialNumberService{
+		RepoSerial:   repoSerial,
+		RepoMovement: repoMovement,
+	}
+}
+
+// FindBySerial mengambil status terkini satu unit beserta seluruh riwayat movement-nya
+func (s *serialNumberService) FindBySerial(serial string) (web.SerialNumberResponse, error) {
+	unit, err := s.RepoSerial.FindBySerial(serial)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.SerialNumberResponse{}, errors.New("serial number not found")
+		}
+		return web.SerialNumberResponse{}, err
+	}
+
+	movements, err := s.RepoMovement.FindBySerialNumberId(unit.ID)
+	if err != nil {
+		return web.SerialNumberResponse{}, err
+	}
+
+	response := web.SerialNumberResponse{
+		Serial:      unit.Serial,
+		ProductID:   unit.ProductID,
+		Status:      unit.Status,
+		WarehouseID: unit.WarehouseID,
+		History:     []web.StockMovementResponse{},
+	}
+	for _, m := range movements {
+		response.History = append(response.History, toStockMovementResponse(m))
+	}
+	return response, nil
+}
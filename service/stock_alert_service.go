@@ -0,0 +1,47 @@
+package service
+
+import (
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+)
+
+type StockAlertService interface {
+	FindAll(filters map[string]interface{}) ([]web.StockAlertResponse, error)
+}
+
+type stockAlertService struct {
+	Repo repository.StockAlertRepository
+}
+
+func NewStockAlertService(repo repository.StockAlertRepository) StockAlertService {
+	return &stockAlertService{Repo: repo}
+}
+
+func (s *stockAlertService) FindAll(filters map[string]interface{}) ([]web.StockAlertResponse, error) {
+	alerts, err := s.Repo.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []web.StockAlertResponse{}
+	for _, a := range alerts {
+		responses = append(responses, toStockAlertResponse(a))
+	}
+	return responses, nil
+}
+
+func toStockAlertResponse(a domain.StockAlert) web.StockAlertResponse {
+	return web.StockAlertResponse{
+		ID:                 a.ID,
+		ProductID:          a.ProductID,
+		Product:            a.Product.Name,
+		Status:             a.Status,
+		Stock:              a.Stock,
+		Threshold:          a.Threshold,
+		MovementID:         a.MovementID,
+		ResolvedMovementID: a.ResolvedMovementID,
+		CreatedAt:          a.CreatedAt,
+		ResolvedAt:         a.ResolvedAt,
+	}
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"sort"
+	"time"
+)
+
+type StockBalanceService interface {
+	ProductBalance(productID int, asOf string, unit string) (web.StockBalanceResponse, error)
+	Report(asOf string, unit string, filters map[string]interface{}) ([]web.StockBalanceResponse, error)
+	TakeSnapshot(date time.Time) error
+}
+
+type stockBalanceService struct {
+	RepoMovement  repository.StockMovementRepository
+	RepoSnapshot  repository.StockSnapshotRepository
+	RepoProduct   repository.ProductRepository
+	RepoStock     repository.ProductStockRepository
+	RepoWarehouse repository.WarehouseRepository
+	RepoUnit      repository.UnitRepository
+}
+
+func NewStockBalanceService(
+	repoMovement repository.StockMovementRepository,
+	repoSnapshot repository.StockSnapshotRepository,
+	repoProduct repository.ProductRepository,
+	repoStock repository.ProductStockRepository,
+	repoWarehouse repository.WarehouseRepository,
+	repoUnit repository.UnitRepository,
+) StockBalanceService {
+	return &stockBalanceService{
+		RepoMovement:  repoMovement,
+		RepoSnapshot:  repoSnapshot,
+		RepoProduct:   repoProduct,
+		RepoStock:     repoStock,
+		RepoWarehouse: repoWarehouse,
+		RepoUnit:      repoUnit,
+	}
+}
+
+type balanceKey struct {
+	ProductID   int
+	WarehouseID int
+}
+
+func (s *stockBalanceService) ProductBalance(productID int, asOf string, unit string) (web.StockBalanceResponse, error) {
+	day, err := parseAsOfDate(asOf)
+	if err != nil {
+		return web.StockBalanceResponse{}, err
+	}
+	product, err := s.RepoProduct.FindById(productID)
+	if err != nil {
+		return web.StockBalanceResponse{}, errors.New("product not found")
+	}
+
+	responses, err := s.balances(day, []domain.Product{product})
+	if err != nil {
+		return web.StockBalanceResponse{}, err
+	}
+	responses, err = s.withDisplayUnit(responses, unit)
+	if err != nil {
+		return web.StockBalanceResponse{}, err
+	}
+	return responses[0], nil
+}
+
+// Report mengembalikan saldo semua produk (bisa difilter category_id) pada akhir hari asOf.
+// Produk yang dibuat setelah tanggal tersebut tidak ditampilkan.
+func (s *stockBalanceService) Report(asOf string, unit string, filters map[string]interface{}) ([]web.StockBalanceResponse, error) {
+	day, err := parseAsOfDate(asOf)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.RepoProduct.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	end := day.AddDate(0, 0, 1)
+	var existing []domain.Product
+	for _, p := range products {
+		if p.CreatedAt.Before(end) {
+			existing = append(existing, p)
+		}
+	}
+	responses, err := s.balances(day, existing)
+	if err != nil {
+		return nil, err
+	}
+	return s.withDisplayUnit(responses, unit)
+}
+
+// withDisplayUnit mengisi DisplayQuantity saldo produk (total dan per gudang) dalam satuan
+// unit. Produk yang tidak punya satuan tersebut dibiarkan tanpa DisplayQuantity.
+func (s *stockBalanceService) withDisplayUnit(responses []web.StockBalanceResponse, unit string) ([]web.StockBalanceResponse, error) {
+	if unit == "" {
+		return responses, nil
+	}
+	factors, err := displayFactors(s.RepoUnit, unit)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range responses {
+		factor, ok := factors[r.ProductID]
+		if !ok {
+			continue
+		}
+		quantity := inUnit(r.Quantity, factor)
+		responses[i].DisplayUnit = unit
+		responses[i].DisplayQuantity = &quantity
+		for j, w := range r.Warehouses {
+			warehouseQuantity := inUnit(w.Quantity, factor)
+			responses[i].Warehouses[j].DisplayQuantity = &warehouseQuantity
+		}
+	}
+	return responses, nil
+}
+
+// TakeSnapshot menyimpan saldo movement semua produk per gudang pada akhir hari date.
+// Hanya hari yang sudah lewat yang bisa di-snapshot.
+func (s *stockBalanceService) TakeSnapshot(date time.Time) error {
+	day := startOfDay(date)
+	end := day.AddDate(0, 0, 1)
+	if end.After(time.Now()) {
+		return errors.New("snapshot date must be in the past")
+	}
+
+	ledger, err := s.ledger(end, 0)
+	if err != nil {
+		return err
+	}
+
+	var snapshots []domain.StockSnapshot
+	for key, quantity := range ledger {
+		if quantity == 0 {
+			continue
+		}
+		snapshots = append(snapshots, domain.StockSnapshot{
+			SnapshotDate: snapshotDate(day),
+			ProductID:    key.ProductID,
+			WarehouseID:  key.WarehouseID,
+			Quantity:     quantity,
+		})
+	}
+	return s.RepoSnapshot.Replace(snapshotDate(day), snapshots)
+}
+
+// balances menghitung saldo produk pada akhir hari day. Saldo = penjumlahan movement sampai
+// hari itu + stok awal. Stok awal adalah selisih stok saat ini dengan seluruh movement,
+// yaitu stok yang diisi langsung pada produk tanpa movement; stok ini dianggap sudah ada
+// sejak produk dibuat.
+func (s *stockBalanceService) balances(day time.Time, products []domain.Product) ([]web.StockBalanceResponse, error) {
+	productID := 0
+	if len(products) == 1 {
+		productID = products[0].ID
+	}
+
+	historical, err := s.ledger(day.AddDate(0, 0, 1), productID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.ledger(time.Now().Add(time.Second), productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var stocks []domain.ProductStock
+	if productID != 0 {
+		stocks, err = s.RepoStock.FindByProductId(productID)
+	} else {
+		stocks, err = s.RepoStock.FindAll()
+	}
+	if err != nil {
+		return nil, err
+	}
+	warehouses, err := s.RepoWarehouse.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	warehouseNames := map[int]string{}
+	for _, w := range warehouses {
+		warehouseNames[w.ID] = w.Name
+	}
+
+	opening := map[balanceKey]int{}
+	for _, st := range stocks {
+		opening[balanceKey{st.ProductID, st.WarehouseID}] += st.Stock
+	}
+	for key, quantity := range current {
+		opening[key] -= quantity
+	}
+
+	perProduct := map[int]map[int]int{}
+	add := func(source map[balanceKey]int) {
+		for key, quantity := range source {
+			if perProduct[key.ProductID] == nil {
+				perProduct[key.ProductID] = map[int]int{}
+			}
+			perProduct[key.ProductID][key.WarehouseID] += quantity
+		}
+	}
+	add(historical)
+	add(opening)
+
+	end := day.AddDate(0, 0, 1)
+	responses := []web.StockBalanceResponse{}
+	for _, p := range products {
+		response := web.StockBalanceResponse{
+			ProductID:  p.ID,
+			Name:       p.Name,
+			CategoryID: p.CategoryID,
+			AsOf:       day.Format("2006-01-02"),
+			Warehouses: []web.WarehouseBalanceResponse{},
+		}
+		if p.CreatedAt.Before(end) {
+			for warehouseID, quantity := range perProduct[p.ID] {
+				if quantity == 0 {
+					continue
+				}
+				response.Quantity += quantity
+				response.Warehouses = append(response.Warehouses, web.WarehouseBalanceResponse{
+					WarehouseID: warehouseID,
+					Warehouse:   warehouseNames[warehouseID],
+					Quantity:    quantity,
+				})
+			}
+		}
+		sort.Slice(response.Warehouses, func(i, j int) bool {
+			return response.Warehouses[i].WarehouseID < response.Warehouses[j].WarehouseID
+		})
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// ledger menjumlahkan movement sebelum waktu before per produk dan gudang, dimulai dari
+// snapshot terakhir yang seluruh harinya sebelum before
+func (s *stockBalanceService) ledger(before time.Time, productID int) (map[balanceKey]int, error) {
+	result := map[balanceKey]int{}
+
+	latest, err := s.RepoSnapshot.LatestDate(snapshotDate(before.AddDate(0, 0, -1)))
+	if err != nil {
+		return nil, err
+	}
+
+	var after *time.Time
+	if latest != nil {
+		snapshots, err := s.RepoSnapshot.FindByDate(*latest, productID)
+		if err != nil {
+			return nil, err
+		}
+		for _, snap := range snapshots {
+			result[balanceKey{snap.ProductID, snap.WarehouseID}] += snap.Quantity
+		}
+		start := time.Date(latest.Year(), latest.Month(), latest.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+		after = &start
+	}
+
+	deltas, err := s.RepoMovement.SumBalances(after, before, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deltas {
+		result[balanceKey{d.ProductID, d.WarehouseID}] += d.Quantity
+	}
+	return result, nil
+}
+
+// parseAsOfDate mengubah parameter as_of (YYYY-MM-DD) menjadi awal hari waktu lokal.
+// Kosong berarti hari ini.
+func parseAsOfDate(asOf string) (time.Time, error) {
+	if asOf == "" {
+		return startOfDay(time.Now()), nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", asOf, time.Local)
+	if err != nil {
+		return time.Time{}, errors.New("invalid as_of date")
+	}
+	return parsed, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// snapshotDate mengubah tanggal lokal menjadi nilai kolom date (tengah malam UTC) agar
+// tanggal yang tersimpan tidak bergeser karena zona waktu
+func snapshotDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StockDocumentService interface {
+	FindAll() ([]web.StockDocumentResponse, error)
+	FindById(id int) (web.StockDocumentResponse, error)
+	Create(userID int, req web.StockDocumentCreateRequest) (web.StockDocumentResponse, error)
+}
+
+// DocumentValidationError dikembalikan jika satu atau lebih baris dokumen tidak valid.
+// Seluruh baris divalidasi terlebih dahulu sehingga semua kesalahan dilaporkan sekaligus.
+type DocumentValidationError struct {
+	Lines []web.StockDocumentLineError
+}
+
+func (e *DocumentValidationError) Error() string {
+	return "document validation failed"
+}
+
+type stockDocumentService struct {
+	Repo            repository.StockDocumentRepository
+	RepoProduct     repository.ProductRepository
+	RepoStock       repository.ProductStockRepository
+	RepoWarehouse   repository.WarehouseRepository
+	RepoReservation repository.ReservationRepository
+	RepoKit         repository.KitRepository
+	MovementService StockMovementService
+	DB              *gorm.DB
+	Validate        *validator.Validate
+}
+
+func NewStockDocumentService(
+	repo repository.StockDocumentRepository,
+	repoProduct repository.ProductRepository,
+	repoStock repository.ProductStockRepository,
+	repoWarehouse repository.WarehouseRepository,
+	repoReservation repository.ReservationRepository,
+	repoKit repository.KitRepository,
+	movementService StockMovementService,
+	db *gorm.DB,
+	validate *validator.Validate,
+) StockDocumentService {
+	return &stockDocumentService{
+		Repo:            repo,
+		RepoProduct:     repoProduct,
+		RepoStock:       repoStock,
+		RepoWarehouse:   repoWarehouse,
+		RepoReservation: repoReservation,
+		RepoKit:         repoKit,
+		MovementService: movementService,
+		DB:              db,
+		Validate:        validate,
+	}
+}
+
+func (s *stockDocumentService) FindAll() ([]web.StockDocumentResponse, error) {
+	documents, err := s.Repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []web.StockDocumentResponse
+	for _, d := range documents {
+		responses = append(responses, toStockDocumentResponse(d))
+	}
+	return responses, nil
+}
+
+func (s *stockDocumentService) FindById(id int) (web.StockDocumentResponse, error) {
+	d, err := s.Repo.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.StockDocumentResponse{}, errors.New("stock document not found")
+		}
+		return web.StockDocumentResponse{}, err
+	}
+	return toStockDocumentResponse(d), nil
+}
+
+// Create memvalidasi seluruh baris lalu memposting semua movement beserta perubahan stoknya
+// dalam satu transaksi. Jika satu baris gagal, tidak ada yang tersimpan.
+func (s *stockDocumentService) Create(userID int, req web.StockDocumentCreateRequest) (web.StockDocumentResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.StockDocumentResponse{}, errors.New("validation failed")
+	}
+
+	if _, err := s.RepoWarehouse.FindById(req.WarehouseID); err != nil {
+		return web.StockDocumentResponse{}, errors.New("warehouse not found")
+	}
+	if req.Type == "transfer" {
+		if req.ToWarehouseID == req.WarehouseID {
+			return web.StockDocumentResponse{}, errors.New("source and destination warehouse must differ")
+		}
+		if _, err := s.RepoWarehouse.FindById(req.ToWarehouseID); err != nil {
+			return web.StockDocumentResponse{}, errors.New("warehouse not found")
+		}
+	}
+
+	if lineErrors := s.validateLines(req); len(lineErrors) > 0 {
+		return web.StockDocumentResponse{}, &DocumentValidationError{Lines: lineErrors}
+	}
+
+	document := domain.StockDocument{
+		Type:            req.Type,
+		ReferenceNumber: req.ReferenceNumber,
+		WarehouseID:     req.WarehouseID,
+		Note:            req.Note,
+		UserID:          userID,
+	}
+	if req.Type == "transfer" {
+		document.ToWarehouseID = &req.ToWarehouseID
+	}
+
+	// Posting diurutkan berdasarkan product_id agar urutan penguncian baris produk konsisten
+	order := make([]int, len(req.Lines))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return req.Lines[order[a]].ProductID < req.Lines[order[b]].ProductID
+	})
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		saved, err := s.Repo.Save(document, tx)
+		if err != nil {
+			return err
+		}
+		document = saved
+
+		for _, i := range order {
+			line := req.Lines[i]
+
+			opts := MovementOptions{LotNumber: line.LotNumber, SerialNumbers: line.SerialNumbers, UnitCost: line.UnitCost}
+			if line.ExpiryDate != "" {
+				expiryDate, err := time.Parse("2006-01-02", line.ExpiryDate)
+				if err != nil {
+					return errors.New("validation failed")
+				}
+				opts.ExpiryDate = &expiryDate
+			}
+
+			note := line.Note
+			if note == "" {
+				note = req.Note
+			}
+
+			_, err := s.MovementService.Post(tx, domain.StockMovement{
+				ProductID:     line.ProductID,
+				UserID:        userID,
+				WarehouseID:   req.WarehouseID,
+				ToWarehouseID: document.ToWarehouseID,
+				Type:          req.Type,
+				Quantity:      line.Quantity,
+				Note:          note,
+				Reference:     stockDocumentReference(document.ID),
+				DocumentID:    &document.ID,
+			}, opts)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return web.StockDocumentResponse{}, err
+	}
+
+	return s.FindById(document.ID)
+}
+
+// validateLines memeriksa semua baris sebelum posting dan mengumpulkan seluruh kesalahannya.
+// Kecukupan stok dihitung kumulatif, jadi dua baris produk yang sama memakai saldo yang sama,
+// dan stok yang ditahan reservasi aktif tidak ikut dihitung. Baris out untuk kit tidak dicek di
+// sini karena kekurangannya dirakit otomatis dari komponen saat posting.
+func (s *stockDocumentService) validateLines(req web.StockDocumentCreateRequest) []web.StockDocumentLineError {
+	var lineErrors []web.StockDocumentLineError
+	remaining := map[int]int{}
+
+	for i, line := range req.Lines {
+		fail := func(msg string) {
+			lineErrors = append(lineErrors, web.StockDocumentLineError{
+				Line:      i + 1,
+				ProductID: line.ProductID,
+				Error:     msg,
+			})
+		}
+
+		product, err := s.RepoProduct.FindById(line.ProductID)
+		if err != nil {
+			fail("product not found")
+			continue
+		}
+		if product.Serialized && len(line.SerialNumbers) != line.Quantity {
+			fail("serial numbers must match quantity")
+			continue
+		}
+		if !product.Serialized && len(line.SerialNumbers) > 0 {
+			fail("product is not serialized")
+			continue
+		}
+		if line.UnitCost != nil && req.Type != "in" {
+			fail("unit cost only allowed for inbound movement")
+			continue
+		}
+
+		if req.Type == "in" {
+			continue
+		}
+		if req.Type == "out" {
+			components, err := s.RepoKit.FindComponents(product.ID)
+			if err != nil {
+				fail(err.Error())
+				continue
+			}
+			if len(components) > 0 {
+				continue
+			}
+		}
+
+		balance, ok := remaining[product.ID]
+		if !ok {
+			stock, err := s.RepoStock.FindByProductAndWarehouse(product.ID, req.WarehouseID)
+			if err != nil {
+				fail(err.Error())
+				continue
+			}
+			reserved, err := s.RepoReservation.SumActiveByWarehouse(product.ID, time.Now())
+			if err != nil {
+				fail(err.Error())
+				continue
+			}
+			balance = stock.Stock - reserved[req.WarehouseID]
+		}
+		if balance < line.Quantity {
+			fail("stock not enough")
+		} else {
+			balance -= line.Quantity
+		}
+		remaining[product.ID] = balance
+	}
+
+	return lineErrors
+}
+
+// stockDocumentReference adalah reference movement dari dokumen stok. Nomor referensi dari
+// user hanya disimpan di dokumen karena reference movement dipakai sistem untuk mencari
+// movement per sumber, misalnya "purchase-order:3".
+func stockDocumentReference(id int) string {
+	return fmt.Sprintf("stock-document:%d", id)
+}
+
+func toStockDocumentResponse(d domain.StockDocument) web.StockDocumentResponse {
+	response := web.StockDocumentResponse{
+		ID:              d.ID,
+		Type:            d.Type,
+		ReferenceNumber: d.ReferenceNumber,
+		WarehouseID:     d.WarehouseID,
+		ToWarehouseID:   d.ToWarehouseID,
+		Note:            d.Note,
+		UserID:          d.UserID,
+		CreatedAt:       d.CreatedAt,
+		Movements:       []web.StockMovementResponse{},
+	}
+	for _, m := range d.Movements {
+		response.Movements = append(response.Movements, toStockMovementResponse(m))
+	}
+	return response
+}
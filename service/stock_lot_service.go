@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"time"
+)
+
+type StockLotService interface {
+	FindAll(filters map[string]interface{}) ([]web.StockLotResponse, error)
+	FindExpiring(days int, filters map[string]interface{}) ([]web.StockLotResponse, error)
+}
+
+type stockLotService struct {
+	Repo repository.StockLotRepository
+}
+
+func NewStockLotService(repo repository.StockLotRepository) StockLotService {
+	return &stockLotService{Repo: repo}
+}
+
+func (s *stockLotService) FindAll(filters map[string]interface{}) ([]web.StockLotResponse, error) {
+	lots, err := s.Repo.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+	return toStockLotResponses(lots), nil
+}
+
+// FindExpiring mengambil lot yang kedaluwarsa dalam days hari ke depan (termasuk yang sudah kedaluwarsa)
+func (s *stockLotService) FindExpiring(days int, filters map[string]interface{}) ([]web.StockLotResponse, error) {
+	if days < 0 {
+		return nil, errors.New("days must not be negative")
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	lots, err := s.Repo.FindExpiring(today.AddDate(0, 0, days), filters)
+	if err != nil {
+		return nil, err
+	}
+	return toStockLotResponses(lots), nil
+}
+
+func toStockLotResponses(lots []domain.StockLot) []web.StockLotResponse {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	var responses []web.StockLotResponse
+	for _, l := range lots {
+		response := web.StockLotResponse{
+			ID:          l.ID,
+			ProductID:   l.ProductID,
+			WarehouseID: l.WarehouseID,
+			LotNumber:   l.LotNumber,
+			ExpiryDate:  formatDate(l.ExpiryDate),
+			Stock:       l.Stock,
+		}
+		if l.ExpiryDate != nil {
+			days := int(l.ExpiryDate.Sub(today).Hours() / 24)
+			response.DaysToExpiry = &days
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
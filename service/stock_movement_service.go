@@ -2,44 +2,83 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"inventory-management-api/model/domain"
 	"inventory-management-api/model/web"
 	"inventory-management-api/repository"
+	"math"
+	"sort"
+	"strings"
+
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 )
 
 type StockMovementService interface {
-	FindAll() ([]web.StockMovementResponse, error)
-	FindById(id int) (web.StockMovementResponse, error)
+	FindAll(unit string) ([]web.StockMovementResponse, error)
+	FindById(id int, unit string) (web.StockMovementResponse, error)
 	Create(userID int, req web.StockMovementCreateRequest) (web.StockMovementResponse, error)
-	Delete(id int) error
-	GetMonthlyReport(month string, filters map[string]interface{}) ([]web.StockMovementResponse, error)
+	Reverse(id int, adminID int, req web.StockMovementReverseRequest) (web.StockMovementResponse, error)
+	Post(tx *gorm.DB, movement domain.StockMovement, opts MovementOptions) (domain.StockMovement, error)
+	RecordDrift(tx *gorm.DB, movement domain.StockMovement) (domain.StockMovement, error)
+	Assemble(tx *gorm.DB, assembly domain.KitAssembly) (domain.KitAssembly, []domain.StockMovement, error)
+	GetMonthlyReport(month string, unit string, filters map[string]interface{}) ([]web.StockMovementResponse, error)
 }
 
 type stockMovementService struct {
-	RepoMovement repository.StockMovementRepository
-	RepoProduct  repository.ProductRepository
-	DB           *gorm.DB
-	Validate     *validator.Validate
+	RepoMovement    repository.StockMovementRepository
+	RepoProduct     repository.ProductRepository
+	RepoStock       repository.ProductStockRepository
+	RepoWarehouse   repository.WarehouseRepository
+	RepoLot         repository.StockLotRepository
+	RepoSerial      repository.SerialNumberRepository
+	RepoReservation repository.ReservationRepository
+	RepoAlert       repository.StockAlertRepository
+	RepoCostLayer   repository.CostLayerRepository
+	RepoUnit        repository.UnitRepository
+	RepoKit         repository.KitRepository
+	CostingMethod   string
+	DB              *gorm.DB
+	Validate        *validator.Validate
 }
 
 func NewStockMovementService(
 	repoMovement repository.StockMovementRepository,
 	repoProduct repository.ProductRepository,
+	repoStock repository.ProductStockRepository,
+	repoWarehouse repository.WarehouseRepository,
+	repoLot repository.StockLotRepository,
+	repoSerial repository.SerialNumberRepository,
+	repoReservation repository.ReservationRepository,
+	repoAlert repository.StockAlertRepository,
+	repoCostLayer repository.CostLayerRepository,
+	repoUnit repository.UnitRepository,
+	repoKit repository.KitRepository,
+	costingMethod string,
 	db *gorm.DB,
 	validate *validator.Validate,
 ) StockMovementService {
 	return &stockMovementService{
-		RepoMovement: repoMovement,
-		RepoProduct:  repoProduct,
-		DB:           db,
-		Validate:     validate,
+		RepoMovement:    repoMovement,
+		RepoProduct:     repoProduct,
+		RepoStock:       repoStock,
+		RepoWarehouse:   repoWarehouse,
+		RepoLot:         repoLot,
+		RepoSerial:      repoSerial,
+		RepoReservation: repoReservation,
+		RepoAlert:       repoAlert,
+		RepoCostLayer:   repoCostLayer,
+		RepoUnit:        repoUnit,
+		RepoKit:         repoKit,
+		CostingMethod:   costingMethod,
+		DB:              db,
+		Validate:        validate,
 	}
 }
 
-func (s *stockMovementService) FindAll() ([]web.StockMovementResponse, error) {
+func (s *stockMovementService) FindAll(unit string) ([]web.StockMovementResponse, error) {
 	movements, err := s.RepoMovement.FindAll()
 	if err != nil {
 		return nil, err
@@ -49,10 +88,10 @@ func (s *stockMovementService) FindAll() ([]web.StockMovementResponse, error) {
 	for _, m := range movements {
 		responses = append(responses, toStockMovementResponse(m))
 	}
-	return responses, nil
+	return s.withDisplayUnit(responses, unit)
 }
 
-func (s *stockMovementService) FindById(id int) (web.StockMovementResponse, error) {
+func (s *stockMovementService) FindById(id int, unit string) (web.StockMovementResponse, error) {
 	m, err := s.RepoMovement.FindById(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -60,76 +99,507 @@ func (s *stockMovementService) FindById(id int) (web.StockMovementResponse, erro
 		}
 		return web.StockMovementResponse{}, err
 	}
-	return toStockMovementResponse(m), nil
+	responses, err := s.withDisplayUnit([]web.StockMovementResponse{toStockMovementResponse(m)}, unit)
+	if err != nil {
+		return web.StockMovementResponse{}, err
+	}
+	return responses[0], nil
+}
+
+// withDisplayUnit mengisi DisplayQuantity dengan Quantity yang dikonversi ke satuan unit.
+// Movement produk yang tidak punya satuan tersebut dibiarkan tanpa DisplayQuantity.
+func (s *stockMovementService) withDisplayUnit(responses []web.StockMovementResponse, unit string) ([]web.StockMovementResponse, error) {
+	if unit == "" {
+		return responses, nil
+	}
+	factors, err := displayFactors(s.RepoUnit, unit)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range responses {
+		if factor, ok := factors[r.ProductID]; ok {
+			quantity := inUnit(r.Quantity, factor)
+			responses[i].DisplayUnit = unit
+			responses[i].DisplayQuantity = &quantity
+		}
+	}
+	return responses, nil
 }
 
 func (s *stockMovementService) Create(userID int, req web.StockMovementCreateRequest) (web.StockMovementResponse, error) {
 	if err := s.Validate.Struct(req); err != nil {
 		return web.StockMovementResponse{}, errors.New("validation failed")
 	}
+	if req.ProductID == 0 {
+		product, err := s.RepoProduct.FindByCode(req.ProductCode)
+		if err != nil {
+			return web.StockMovementResponse{}, err
+		}
+		req.ProductID = product.ID
+	}
 
-	tx := s.DB.Begin()
+	movement := domain.StockMovement{
+		ProductID:   req.ProductID,
+		UserID:      userID,
+		WarehouseID: req.WarehouseID,
+		Type:        req.Type,
+		Quantity:    req.Quantity,
+		Note:        req.Note,
+	}
+	if req.Type == "transfer" {
+		movement.ToWarehouseID = &req.ToWarehouseID
+	}
 
-	// Cari produk
-	product, err := s.RepoProduct.FindById(req.ProductID)
-	if err != nil {
-		tx.Rollback()
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return web.StockMovementResponse{}, errors.New("product not found")
+	// Quantity yang diinput dalam satuan alternatif dikonversi ke satuan dasar produk
+	factor := 1
+	if req.Unit != "" {
+		product, err := s.RepoProduct.FindById(req.ProductID)
+		if err != nil {
+			return web.StockMovementResponse{}, err
 		}
-		return web.StockMovementResponse{}, err
+		unit, unitFactor, err := resolveUnit(s.RepoUnit, product, req.Unit)
+		if err != nil {
+			return web.StockMovementResponse{}, err
+		}
+		factor = unitFactor
+		movement.Quantity = req.Quantity * factor
+		movement.UnitID = &unit.ID
 	}
 
-	// Validasi dan update stok
-	if req.Type == "in" {
-		product.Stock += req.Quantity
-	} else if req.Type == "out" {
-		if product.Stock < req.Quantity {
-			tx.Rollback()
-			return web.StockMovementResponse{}, errors.New("stock not enough")
+	opts := MovementOptions{LotNumber: req.LotNumber, SerialNumbers: req.SerialNumbers}
+	if req.ReservationID != 0 {
+		opts.ReservationID = &req.ReservationID
+	}
+	if req.UnitCost != nil {
+		if req.Type != "in" {
+			return web.StockMovementResponse{}, errors.New("unit cost only allowed for inbound movement")
 		}
-		product.Stock -= req.Quantity
+		// Harga per satuan input dikonversi menjadi harga per satuan dasar
+		unitCost := *req.UnitCost / float64(factor)
+		opts.UnitCost = &unitCost
+	}
+	if req.ExpiryDate != "" {
+		expiryDate, err := time.Parse("2006-01-02", req.ExpiryDate)
+		if err != nil {
+			return web.StockMovementResponse{}, errors.New("validation failed")
+		}
+		opts.ExpiryDate = &expiryDate
 	}
 
-	// Simpan update stock product
-	_, err = s.RepoProduct.Update(product)
+	// Kunci produk, update stok, dan simpan movement dalam satu transaksi
+	var saved domain.StockMovement
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		saved, err = s.applyMovement(tx, movement, opts)
+		return err
+	})
 	if err != nil {
-		tx.Rollback()
 		return web.StockMovementResponse{}, err
 	}
 
-	// Simpan movement
-	movement := domain.StockMovement{
-		ProductID: req.ProductID,
-		UserID:    userID,
-		Type:      req.Type,
-		Quantity:  req.Quantity,
-		Note:      req.Note,
+	return s.FindById(saved.ID, "")
+}
+
+// MovementOptions berisi detail tambahan sebuah movement. Untuk movement masuk, LotNumber dan
+// ExpiryDate mencatat lot yang diterima; untuk keluar/transfer LotNumber memilih lot
+// tertentu, jika kosong lot dialokasikan otomatis secara FEFO. SerialNumbers wajib diisi
+// untuk produk serialized dengan jumlah sama dengan Quantity. ReservationID (khusus
+// movement keluar) memakai stok yang sebelumnya ditahan reservasi tersebut. UnitCost adalah
+// harga beli per unit movement masuk, jika kosong memakai average cost produk; untuk
+// movement keluar hanya diisi oleh pembalikan agar nilai dikembalikan dengan harga aslinya.
+type MovementOptions struct {
+	LotNumber     string
+	ExpiryDate    *time.Time
+	SerialNumbers []string
+	ReservationID *int
+	UnitCost      *float64
+}
+
+// Post memposting movement di dalam transaksi tx milik pemanggil. Dipakai oleh service lain
+// (misalnya stock opname) yang perlu menggabungkan movement dengan perubahan datanya sendiri.
+func (s *stockMovementService) Post(tx *gorm.DB, movement domain.StockMovement, opts MovementOptions) (domain.StockMovement, error) {
+	return s.applyMovement(tx, movement, opts)
+}
+
+// RecordDrift mencatat movement adjust untuk perubahan stok yang sudah terjadi tanpa movement
+// (hasil rekonsiliasi). Saldo stok tidak diubah lagi, tetapi lot dan cost layer disesuaikan
+// seperti adjust biasa agar tetap sejalan dengan stok. Harus dipanggil di dalam transaksi.
+func (s *stockMovementService) RecordDrift(tx *gorm.DB, movement domain.StockMovement) (domain.StockMovement, error) {
+	if movement.Type != "adjust" {
+		return domain.StockMovement{}, errors.New("drift must be recorded as adjust movement")
+	}
+	movement.UnitQuantity = movement.Quantity
+
+	product, err := s.RepoProduct.FindByIdForUpdate(movement.ProductID, tx)
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+	source, err := s.RepoStock.FindOrCreateForUpdate(product.ID, movement.WarehouseID, tx)
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	// Lot dialokasikan terhadap saldo gudang sebelum perubahan yang tidak tercatat
+	if err := s.allocateLots(tx, &movement, source.Stock-movement.Quantity, MovementOptions{}); err != nil {
+		return domain.StockMovement{}, err
+	}
+	layer, err := s.applyCost(tx, &movement, product, MovementOptions{})
+	if err != nil {
+		return domain.StockMovement{}, err
 	}
 
 	saved, err := s.RepoMovement.Save(movement, tx)
 	if err != nil {
-		tx.Rollback()
-		return web.StockMovementResponse{}, err
+		return domain.StockMovement{}, err
+	}
+	if layer != nil {
+		layer.MovementID = saved.ID
+		if _, err := s.RepoCostLayer.Save(*layer, tx); err != nil {
+			return domain.StockMovement{}, err
+		}
+	}
+	return saved, nil
+}
+
+// applyMovement mengunci baris produk, memvalidasi dan mengubah stok (total dan per gudang),
+// lalu menyimpan movement. Harus dipanggil di dalam transaksi agar cek stok dan update
+// tidak balapan.
+func (s *stockMovementService) applyMovement(tx *gorm.DB, movement domain.StockMovement, opts MovementOptions) (domain.StockMovement, error) {
+	if movement.UnitID == nil {
+		movement.UnitQuantity = movement.Quantity
+	}
+
+	// Kit yang stok rakitannya kurang dirakit otomatis dari komponennya lebih dulu
+	if err := s.assembleShortfall(tx, movement, opts); err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	product, err := s.RepoProduct.FindByIdForUpdate(movement.ProductID, tx)
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+	// Stok produk induk dicatat pada variannya
+	hasVariants, err := s.RepoProduct.HasVariants(product.ID)
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+	if hasVariants {
+		return domain.StockMovement{}, errors.New("parent product cannot hold stock")
 	}
 
-	tx.Commit()
-	return toStockMovementResponse(saved), nil
+	if _, err := s.RepoWarehouse.FindById(movement.WarehouseID); err != nil {
+		return domain.StockMovement{}, errors.New("warehouse not found")
+	}
+
+	source, err := s.RepoStock.FindOrCreateForUpdate(product.ID, movement.WarehouseID, tx)
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	if movement.Type == "transfer" {
+		if movement.ToWarehouseID == nil || *movement.ToWarehouseID == movement.WarehouseID {
+			return domain.StockMovement{}, errors.New("source and destination warehouse must differ")
+		}
+		if _, err := s.RepoWarehouse.FindById(*movement.ToWarehouseID); err != nil {
+			return domain.StockMovement{}, errors.New("warehouse not found")
+		}
+	}
+	if outgoingQuantity(movement) > source.Stock {
+		return domain.StockMovement{}, errors.New("stock not enough")
+	}
+	if err := s.applyReservation(tx, &movement, source.Stock, opts); err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	if err := s.allocateLots(tx, &movement, source.Stock, opts); err != nil {
+		return domain.StockMovement{}, err
+	}
+	if err := s.applySerials(tx, &movement, product, opts.SerialNumbers); err != nil {
+		return domain.StockMovement{}, err
+	}
+	layer, err := s.applyCost(tx, &movement, product, opts)
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	// Update stok
+	switch movement.Type {
+	case "in":
+		source.Stock += movement.Quantity
+		product.Stock += movement.Quantity
+	case "out":
+		source.Stock -= movement.Quantity
+		product.Stock -= movement.Quantity
+	case "adjust":
+		// Quantity adjust bertanda: positif menambah, negatif mengurangi stok
+		source.Stock += movement.Quantity
+		product.Stock += movement.Quantity
+	case "transfer":
+		// Transfer hanya memindahkan saldo antar gudang, total stok produk tidak berubah
+		destination, err := s.RepoStock.FindOrCreateForUpdate(product.ID, *movement.ToWarehouseID, tx)
+		if err != nil {
+			return domain.StockMovement{}, err
+		}
+		source.Stock -= movement.Quantity
+		if err := s.RepoStock.UpdateStock(destination.ID, destination.Stock+movement.Quantity, tx); err != nil {
+			return domain.StockMovement{}, err
+		}
+	}
+
+	if err := s.RepoStock.UpdateStock(source.ID, source.Stock, tx); err != nil {
+		return domain.StockMovement{}, err
+	}
+	if err := s.RepoProduct.UpdateStock(product.ID, product.Stock, tx); err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	saved, err := s.RepoMovement.Save(movement, tx)
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+	if layer != nil {
+		layer.MovementID = saved.ID
+		if _, err := s.RepoCostLayer.Save(*layer, tx); err != nil {
+			return domain.StockMovement{}, err
+		}
+	}
+	if err := s.checkStockAlert(tx, product, saved.ID); err != nil {
+		return domain.StockMovement{}, err
+	}
+	return saved, nil
 }
 
-func (s *stockMovementService) Delete(id int) error {
-	_, err := s.RepoMovement.FindById(id)
+// checkStockAlert membuat alert saat stok produk (setelah movement) di bawah ambang batas
+// dan belum ada alert open, atau menyelesaikan alert open jika stok sudah pulih.
+// Baris produk sudah dikunci applyMovement sehingga alert tidak dobel.
+func (s *stockMovementService) checkStockAlert(tx *gorm.DB, product domain.Product, movementID int) error {
+	alert, open, err := s.RepoAlert.FindOpenByProduct(product.ID, tx)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("stock movement not found")
+		return err
+	}
+
+	if isLowStock(product) {
+		if open {
+			return nil
 		}
+		_, err := s.RepoAlert.Save(domain.StockAlert{
+			ProductID:  product.ID,
+			Status:     "open",
+			Stock:      product.Stock,
+			Threshold:  lowStockThreshold(product),
+			MovementID: &movementID,
+		}, tx)
+		return err
+	}
+
+	if open {
+		return s.RepoAlert.Resolve(alert.ID, movementID, time.Now(), tx)
+	}
+	return nil
+}
+
+// Assemble merakit assembly.Quantity kit dari komponennya di gudang assembly.WarehouseID di
+// dalam transaksi tx milik pemanggil. Dikembalikan movement out setiap komponen diikuti
+// movement in kit-nya.
+func (s *stockMovementService) Assemble(tx *gorm.DB, assembly domain.KitAssembly) (domain.KitAssembly, []domain.StockMovement, error) {
+	// Kit dikunci lebih dulu seperti pada perakitan otomatis agar urutan penguncian konsisten
+	if _, err := s.RepoProduct.FindByIdForUpdate(assembly.KitID, tx); err != nil {
+		return domain.KitAssembly{}, nil, err
+	}
+	components, err := s.RepoKit.FindComponents(assembly.KitID)
+	if err != nil {
+		return domain.KitAssembly{}, nil, err
+	}
+	if len(components) == 0 {
+		return domain.KitAssembly{}, nil, errors.New("product is not a kit")
+	}
+	if _, err := s.RepoWarehouse.FindById(assembly.WarehouseID); err != nil {
+		return domain.KitAssembly{}, nil, errors.New("warehouse not found")
+	}
+	return s.assemble(tx, assembly, components)
+}
+
+// assembleShortfall merakit otomatis kekurangan stok kit sebelum movement out kit diposting,
+// yaitu selisih quantity dengan stok kit di gudang yang tidak ditahan reservasi. Movement
+// yang memakai reservasi dan movement pembalik hanya memakai stok kit yang sudah dirakit.
+func (s *stockMovementService) assembleShortfall(tx *gorm.DB, movement domain.StockMovement, opts MovementOptions) error {
+	if movement.Type != "out" || opts.ReservationID != nil || movement.ReversalOfID != nil {
+		return nil
+	}
+	components, err := s.RepoKit.FindComponents(movement.ProductID)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		return nil
+	}
+
+	if _, err := s.RepoProduct.FindByIdForUpdate(movement.ProductID, tx); err != nil {
+		return err
+	}
+	if _, err := s.RepoWarehouse.FindById(movement.WarehouseID); err != nil {
+		return errors.New("warehouse not found")
+	}
+	stock, err := s.RepoStock.FindOrCreateForUpdate(movement.ProductID, movement.WarehouseID, tx)
+	if err != nil {
 		return err
 	}
+	reserved, err := s.RepoReservation.SumActive(movement.ProductID, movement.WarehouseID, time.Now(), tx)
+	if err != nil {
+		return err
+	}
+
+	shortfall := movement.Quantity - max(stock.Stock-reserved, 0)
+	if shortfall <= 0 {
+		return nil
+	}
+	_, _, err = s.assemble(tx, domain.KitAssembly{
+		KitID:       movement.ProductID,
+		WarehouseID: movement.WarehouseID,
+		Quantity:    shortfall,
+		Automatic:   true,
+		UserID:      movement.UserID,
+		Note:        movement.Note,
+	}, components)
+	return err
+}
+
+// assemble mencatat perakitan, memposting movement out setiap komponen, lalu movement in
+// kit. Harga pokok kit adalah total harga pokok komponen yang dipakai dibagi jumlah kit.
+func (s *stockMovementService) assemble(tx *gorm.DB, assembly domain.KitAssembly, components []domain.KitComponent) (domain.KitAssembly, []domain.StockMovement, error) {
+	saved, err := s.RepoKit.SaveAssembly(assembly, tx)
+	if err != nil {
+		return domain.KitAssembly{}, nil, err
+	}
+	reference := kitAssemblyReference(saved.ID)
+	note := saved.Note
+	if note == "" {
+		note = fmt.Sprintf("Perakitan kit #%d", saved.ID)
+	}
+
+	var movements []domain.StockMovement
+	totalCost := 0.0
+	for _, c := range components {
+		m, err := s.applyMovement(tx, domain.StockMovement{
+			ProductID:   c.ComponentID,
+			UserID:      saved.UserID,
+			WarehouseID: saved.WarehouseID,
+			Type:        "out",
+			Quantity:    c.Quantity * saved.Quantity,
+			Note:        note,
+			Reference:   reference,
+		}, MovementOptions{})
+		if err != nil {
+			if err.Error() == "stock not enough" || err.Error() == "available stock not enough" {
+				return domain.KitAssembly{}, nil, errors.New("component stock not enough")
+			}
+			return domain.KitAssembly{}, nil, err
+		}
+		totalCost += m.TotalCost
+		movements = append(movements, m)
+	}
+
+	unitCost := totalCost / float64(saved.Quantity)
+	kit, err := s.applyMovement(tx, domain.StockMovement{
+		ProductID:   saved.KitID,
+		UserID:      saved.UserID,
+		WarehouseID: saved.WarehouseID,
+		Type:        "in",
+		Quantity:    saved.Quantity,
+		Note:        note,
+		Reference:   reference,
+	}, MovementOptions{UnitCost: &unitCost})
+	if err != nil {
+		return domain.KitAssembly{}, nil, err
+	}
+	return saved, append(movements, kit), nil
+}
+
+func kitAssemblyReference(id int) string {
+	return fmt.Sprintf("kit-assembly:%d", id)
+}
+
+// Reverse membatalkan movement dengan memposting movement kebalikannya. Movement asli
+// tetap tersimpan dan ditandai sudah dibalik, sehingga riwayat stok tetap utuh.
+func (s *stockMovementService) Reverse(id int, adminID int, req web.StockMovementReverseRequest) (web.StockMovementResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.StockMovementResponse{}, errors.New("validation failed")
+	}
+
+	var saved domain.StockMovement
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		// Kunci movement asli agar tidak bisa dibalik dua kali secara bersamaan
+		original, err := s.RepoMovement.FindByIdForUpdate(id, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("stock movement not found")
+			}
+			return err
+		}
+		if original.ReversalOfID != nil {
+			return errors.New("cannot reverse a reversal movement")
+		}
+		if original.ReversalID != nil {
+			return errors.New("stock movement already reversed")
+		}
+		// Adjust rekonsiliasi hanya mencatat selisih yang sudah terjadi, membaliknya akan mengubah stok
+		if strings.HasPrefix(original.Reference, "reconciliation:") {
+			return errors.New("cannot reverse a reconciliation adjustment")
+		}
+		// Penerimaan PO dan pengiriman SO juga tercatat di baris order, membalik movement-nya
+		// saja membuat jumlah received/shipped tidak sesuai lagi dengan stok
+		if strings.HasPrefix(original.Reference, "purchase-order:") || strings.HasPrefix(original.Reference, "sales-order:") {
+			return errors.New("cannot reverse an order movement")
+		}
+
+		reversal := domain.StockMovement{
+			ProductID:    original.ProductID,
+			UserID:       adminID,
+			WarehouseID:  original.WarehouseID,
+			Type:         oppositeMovementType(original.Type),
+			Quantity:     original.Quantity,
+			UnitID:       original.UnitID,
+			UnitQuantity: original.UnitQuantity,
+			Note:         req.Reason,
+			Reference:    original.Reference,
+			ReversalOfID: &original.ID,
+		}
+		if original.Type == "adjust" {
+			reversal.Quantity = -original.Quantity
+			reversal.UnitQuantity = -original.UnitQuantity
+		}
+		// Transfer dibalik dengan memindahkan kembali dari gudang tujuan ke gudang asal
+		if original.Type == "transfer" && original.ToWarehouseID != nil {
+			reversal.WarehouseID = *original.ToWarehouseID
+			reversal.ToWarehouseID = &original.WarehouseID
+		}
+
+		// Pembalikan memakai lot dan serial number yang sama persis dengan movement asli
+		reversal.Lots, err = s.reversalLots(tx, original)
+		if err != nil {
+			return err
+		}
+		// Nilai dikembalikan dengan harga per unit movement asli
+		opts := MovementOptions{UnitCost: &original.UnitCost}
+		for _, ms := range original.Serials {
+			opts.SerialNumbers = append(opts.SerialNumbers, ms.SerialNumber.Serial)
+		}
+
+		saved, err = s.applyMovement(tx, reversal, opts)
+		if err != nil {
+			return err
+		}
+
+		return s.RepoMovement.MarkReversed(original.ID, saved.ID, tx)
+	})
+	if err != nil {
+		return web.StockMovementResponse{}, err
+	}
 
-	return s.RepoMovement.Delete(id)
+	return s.FindById(saved.ID, "")
 }
 
-func (s *stockMovementService) GetMonthlyReport(month string, filters map[string]interface{}) ([]web.StockMovementResponse, error) {
+func (s *stockMovementService) GetMonthlyReport(month string, unit string, filters map[string]interface{}) ([]web.StockMovementResponse, error) {
 	movements, err := s.RepoMovement.FindByMonth(month, filters)
 	if err != nil {
 		return nil, err
@@ -144,17 +614,456 @@ func (s *stockMovementService) GetMonthlyReport(month string, filters map[string
 	for _, m := range movements {
 		responses = append(responses, toStockMovementResponse(m))
 	}
-	return responses, nil
+	return s.withDisplayUnit(responses, unit)
 }
 
 func toStockMovementResponse(m domain.StockMovement) web.StockMovementResponse {
-	return web.StockMovementResponse{
-		ID:        m.ID,
-		ProductID: m.ProductID,
-		UserID:    m.UserID,
-		Type:      m.Type,
-		Quantity:  m.Quantity,
-		Note:      m.Note,
-		CreatedAt: m.CreatedAt,
+	response := web.StockMovementResponse{
+		ID:            m.ID,
+		ProductID:     m.ProductID,
+		UserID:        m.UserID,
+		WarehouseID:   m.WarehouseID,
+		ToWarehouseID: m.ToWarehouseID,
+		Type:          m.Type,
+		Quantity:      m.Quantity,
+		Note:          m.Note,
+		Reference:     m.Reference,
+		DocumentID:    m.DocumentID,
+		ReservationID: m.ReservationID,
+		UnitCost:      m.UnitCost,
+		TotalCost:     m.TotalCost,
+		CreatedAt:     m.CreatedAt,
+
+		Reversed:     m.ReversalID != nil,
+		ReversalID:   m.ReversalID,
+		ReversalOfID: m.ReversalOfID,
+
+		Lots:          toStockMovementLotResponses(m.Lots),
+		SerialNumbers: toSerialNumberList(m.Serials),
+	}
+	if m.Unit != nil {
+		response.Unit = m.Unit.Code
+		response.UnitQuantity = m.UnitQuantity
+	}
+	return response
+}
+
+func toSerialNumberList(serials []domain.StockMovementSerial) []string {
+	var list []string
+	for _, ms := range serials {
+		list = append(list, ms.SerialNumber.Serial)
+	}
+	return list
+}
+
+func toStockMovementLotResponses(lots []domain.StockMovementLot) []web.StockMovementLotResponse {
+	var responses []web.StockMovementLotResponse
+	for _, l := range lots {
+		responses = append(responses, web.StockMovementLotResponse{
+			LotID:      l.LotID,
+			LotNumber:  l.Lot.LotNumber,
+			ExpiryDate: formatDate(l.Lot.ExpiryDate),
+			Quantity:   l.Quantity,
+		})
+	}
+	return responses
+}
+
+func oppositeMovementType(movementType string) string {
+	switch movementType {
+	case "in":
+		return "out"
+	case "out":
+		return "in"
+	}
+	return movementType
+}
+
+// outgoingQuantity adalah jumlah yang keluar dari gudang asal movement
+func outgoingQuantity(m domain.StockMovement) int {
+	switch m.Type {
+	case "out", "transfer":
+		return m.Quantity
+	case "adjust":
+		if m.Quantity < 0 {
+			return -m.Quantity
+		}
+	}
+	return 0
+}
+
+// applyReservation memastikan movement keluar tidak memakai stok yang ditahan reservasi
+// aktif. Jika opts.ReservationID diisi, stok reservasi tersebut boleh dipakai dan sisa
+// reservasinya dikurangi. Adjust dan movement pembalik hanya mengoreksi stok fisik,
+// jadi tidak dibatasi reservasi.
+func (s *stockMovementService) applyReservation(tx *gorm.DB, movement *domain.StockMovement, warehouseStock int, opts MovementOptions) error {
+	if opts.ReservationID == nil && (outgoingQuantity(*movement) == 0 || movement.Type == "adjust" || movement.ReversalOfID != nil) {
+		return nil
+	}
+
+	now := time.Now()
+	reserved, err := s.RepoReservation.SumActive(movement.ProductID, movement.WarehouseID, now, tx)
+	if err != nil {
+		return err
+	}
+
+	if opts.ReservationID != nil {
+		if movement.Type != "out" {
+			return errors.New("reservation can only be consumed by out movement")
+		}
+		r, err := s.RepoReservation.FindByIdForUpdate(*opts.ReservationID, tx)
+		if err != nil {
+			return err
+		}
+		if r.ProductID != movement.ProductID || r.WarehouseID != movement.WarehouseID {
+			return errors.New("reservation does not match product or warehouse")
+		}
+		if !isReservationActive(r, now) {
+			return errors.New("reservation is not active")
+		}
+		if movement.Quantity > r.Quantity {
+			return errors.New("quantity exceeds reservation")
+		}
+
+		// Stok yang ditahan reservasi ini sendiri boleh dipakai
+		reserved -= r.Quantity
+
+		status := "active"
+		if r.Quantity == movement.Quantity {
+			status = "consumed"
+		}
+		if err := s.RepoReservation.UpdateQuantity(r.ID, r.Quantity-movement.Quantity, status, tx); err != nil {
+			return err
+		}
+		movement.ReservationID = &r.ID
+	}
+
+	if movement.Quantity > warehouseStock-reserved {
+		return errors.New("available stock not enough")
+	}
+	return nil
+}
+
+// applyCost menghitung nilai movement. product adalah kondisi sebelum stok movement
+// diterapkan. Movement masuk (dan adjust positif) membuat cost layer baru yang dikembalikan
+// untuk disimpan setelah movement punya ID. Transfer tidak mengubah nilai persediaan.
+func (s *stockMovementService) applyCost(tx *gorm.DB, movement *domain.StockMovement, product domain.Product, opts MovementOptions) (*domain.CostLayer, error) {
+	if movement.Type == "transfer" || movement.Quantity == 0 {
+		return nil, nil
+	}
+	if quantity := outgoingQuantity(*movement); quantity > 0 {
+		return nil, s.issueCost(tx, movement, product, quantity, opts)
+	}
+
+	unitCost := product.AverageCost
+	if opts.UnitCost != nil {
+		unitCost = *opts.UnitCost
+	}
+	movement.UnitCost = roundUnitCost(unitCost)
+	movement.TotalCost = roundMoney(unitCost * float64(movement.Quantity))
+
+	// Moving weighted average dihitung ulang setiap ada barang masuk
+	stock := product.Stock + movement.Quantity
+	if stock > 0 {
+		average := (float64(product.Stock)*product.AverageCost + float64(movement.Quantity)*unitCost) / float64(stock)
+		if err := s.RepoProduct.UpdateAverageCost(product.ID, roundUnitCost(average), tx); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.CostLayer{
+		ProductID: product.ID,
+		Quantity:  movement.Quantity,
+		Remaining: movement.Quantity,
+		UnitCost:  movement.UnitCost,
+	}, nil
+}
+
+// issueCost menghitung harga pokok (COGS) movement keluar. Cost layer selalu diambil secara
+// FIFO agar sisa layer sama dengan stok, tetapi nilainya mengikuti metode costing: total
+// harga layer untuk "fifo", atau quantity x average cost untuk "average". Pembalikan
+// movement masuk mengambil layer movement aslinya terlebih dahulu.
+func (s *stockMovementService) issueCost(tx *gorm.DB, movement *domain.StockMovement, product domain.Product, quantity int, opts MovementOptions) error {
+	layers, err := s.RepoCostLayer.FindOpenForUpdate(product.ID, tx)
+	if err != nil {
+		return err
+	}
+	if movement.ReversalOfID != nil {
+		sort.SliceStable(layers, func(i, j int) bool {
+			return layers[i].MovementID == *movement.ReversalOfID && layers[j].MovementID != *movement.ReversalOfID
+		})
+	}
+
+	fifoCost := 0.0
+	remaining := quantity
+	for _, l := range layers {
+		if remaining == 0 {
+			break
+		}
+		taken := min(l.Remaining, remaining)
+		if err := s.RepoCostLayer.UpdateRemaining(l.ID, l.Remaining-taken, tx); err != nil {
+			return err
+		}
+		fifoCost += float64(taken) * l.UnitCost
+		remaining -= taken
+	}
+	// Stok tanpa cost layer (misalnya stok awal produk) dinilai dengan average cost
+	fifoCost += float64(remaining) * product.AverageCost
+
+	total := fifoCost
+	if s.CostingMethod == "average" {
+		total = float64(quantity) * product.AverageCost
+	}
+	if opts.UnitCost != nil {
+		total = float64(quantity) * *opts.UnitCost
+
+		// Mengeluarkan barang dengan harga selain average mengubah average sisa stok
+		if stock := product.Stock - quantity; stock > 0 {
+			average := max((float64(product.Stock)*product.AverageCost-total)/float64(stock), 0)
+			if err := s.RepoProduct.UpdateAverageCost(product.ID, roundUnitCost(average), tx); err != nil {
+				return err
+			}
+		}
+	}
+
+	movement.TotalCost = roundMoney(total)
+	movement.UnitCost = roundUnitCost(total / float64(quantity))
+	return nil
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func roundUnitCost(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// allocateLots mencatat lot mana saja yang dipakai movement dan memperbarui saldo lotnya.
+// warehouseStock adalah saldo gudang asal sebelum movement diterapkan.
+func (s *stockMovementService) allocateLots(tx *gorm.DB, movement *domain.StockMovement, warehouseStock int, opts MovementOptions) error {
+	if outgoingQuantity(*movement) == 0 {
+		return s.putIntoLots(tx, movement, opts)
+	}
+
+	taken := *movement
+	taken.Quantity = outgoingQuantity(*movement)
+	allocations, err := s.takeFromLots(tx, taken, warehouseStock, opts)
+	if err != nil {
+		return err
+	}
+
+	// Lot yang ditransfer dibuat (atau ditambah) di gudang tujuan dengan nomor dan kedaluwarsa yang sama
+	if movement.Type == "transfer" {
+		for _, a := range allocations {
+			destination, _, err := s.RepoLot.FindOrCreateForUpdate(movement.ProductID, *movement.ToWarehouseID, a.Lot.LotNumber, a.Lot.ExpiryDate, tx)
+			if err != nil {
+				return err
+			}
+			if err := s.RepoLot.UpdateStock(destination.ID, destination.Stock+a.Quantity, tx); err != nil {
+				return err
+			}
+		}
+	}
+
+	movement.Lots = nil
+	for _, a := range allocations {
+		movement.Lots = append(movement.Lots, domain.StockMovementLot{LotID: a.LotID, Quantity: a.Quantity})
+	}
+	return nil
+}
+
+func (s *stockMovementService) putIntoLots(tx *gorm.DB, movement *domain.StockMovement, opts MovementOptions) error {
+	// Alokasi eksplisit (pembalikan movement keluar): kembalikan ke lot yang sama
+	if len(movement.Lots) > 0 {
+		for _, a := range movement.Lots {
+			l, err := s.RepoLot.FindByIdForUpdate(a.LotID, tx)
+			if err != nil {
+				return err
+			}
+			if err := s.RepoLot.UpdateStock(l.ID, l.Stock+a.Quantity, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if opts.LotNumber == "" {
+		return nil
+	}
+
+	l, created, err := s.RepoLot.FindOrCreateForUpdate(movement.ProductID, movement.WarehouseID, opts.LotNumber, opts.ExpiryDate, tx)
+	if err != nil {
+		return err
+	}
+	if !created && opts.ExpiryDate != nil && formatDate(l.ExpiryDate) != formatDate(opts.ExpiryDate) {
+		return errors.New("lot expiry date mismatch")
+	}
+	if err := s.RepoLot.UpdateStock(l.ID, l.Stock+movement.Quantity, tx); err != nil {
+		return err
+	}
+
+	movement.Lots = []domain.StockMovementLot{{LotID: l.ID, Quantity: movement.Quantity}}
+	return nil
+}
+
+// takeFromLots mengurangi saldo lot untuk movement keluar/transfer. Urutan prioritas:
+// alokasi eksplisit (pembalikan), lot yang disebut di request, lalu FEFO atas lot yang
+// belum kedaluwarsa. Sisa kuantitas yang tidak tertutup lot diambil dari stok tanpa lot.
+func (s *stockMovementService) takeFromLots(tx *gorm.DB, movement domain.StockMovement, warehouseStock int, opts MovementOptions) ([]domain.StockMovementLot, error) {
+	var allocations []domain.StockMovementLot
+
+	take := func(l domain.StockLot, quantity int) error {
+		if l.Stock < quantity {
+			return errors.New("lot stock not enough")
+		}
+		if err := s.RepoLot.UpdateStock(l.ID, l.Stock-quantity, tx); err != nil {
+			return err
+		}
+		allocations = append(allocations, domain.StockMovementLot{LotID: l.ID, Quantity: quantity, Lot: l})
+		return nil
+	}
+
+	if len(movement.Lots) > 0 {
+		for _, a := range movement.Lots {
+			l, err := s.RepoLot.FindByIdForUpdate(a.LotID, tx)
+			if err != nil {
+				return nil, err
+			}
+			if err := take(l, a.Quantity); err != nil {
+				return nil, err
+			}
+		}
+		return allocations, nil
+	}
+
+	if opts.LotNumber != "" {
+		l, err := s.RepoLot.FindByNumberForUpdate(movement.ProductID, movement.WarehouseID, opts.LotNumber, tx)
+		if err != nil {
+			return nil, err
+		}
+		if err := take(l, movement.Quantity); err != nil {
+			return nil, err
+		}
+		return allocations, nil
+	}
+
+	lotTotal, err := s.RepoLot.SumStock(movement.ProductID, movement.WarehouseID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust (hasil stock opname) juga boleh mengurangi lot yang sudah kedaluwarsa
+	today := time.Now().Truncate(24 * time.Hour)
+	if movement.Type == "adjust" {
+		today = time.Time{}
+	}
+	lots, err := s.RepoLot.FindAvailableForUpdate(movement.ProductID, movement.WarehouseID, today, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := movement.Quantity
+	for _, l := range lots {
+		if remaining == 0 {
+			break
+		}
+		quantity := min(l.Stock, remaining)
+		if err := take(l, quantity); err != nil {
+			return nil, err
+		}
+		remaining -= quantity
+	}
+
+	// Lot yang sudah kedaluwarsa tidak dialokasikan otomatis, jadi sisa harus muat di stok tanpa lot
+	if remaining > warehouseStock-lotTotal {
+		return nil, errors.New("stock not enough")
+	}
+	return allocations, nil
+}
+
+// reversalLots menyiapkan alokasi lot untuk movement pembalik. Untuk transfer, lot
+// diambil dari gudang tujuan dengan nomor lot yang sama.
+func (s *stockMovementService) reversalLots(tx *gorm.DB, original domain.StockMovement) ([]domain.StockMovementLot, error) {
+	var lots []domain.StockMovementLot
+	for _, a := range original.Lots {
+		lotID := a.LotID
+		if original.Type == "transfer" && original.ToWarehouseID != nil {
+			l, err := s.RepoLot.FindByNumberForUpdate(original.ProductID, *original.ToWarehouseID, a.Lot.LotNumber, tx)
+			if err != nil {
+				return nil, err
+			}
+			lotID = l.ID
+		}
+		lots = append(lots, domain.StockMovementLot{LotID: lotID, Quantity: a.Quantity})
+	}
+	return lots, nil
+}
+
+// applySerials memvalidasi dan memperbarui status unit serial yang ikut dalam movement.
+// Produk serialized wajib menyebut serial number sebanyak Quantity.
+func (s *stockMovementService) applySerials(tx *gorm.DB, movement *domain.StockMovement, product domain.Product, serials []string) error {
+	if !product.Serialized {
+		if len(serials) > 0 {
+			return errors.New("product is not serialized")
+		}
+		return nil
+	}
+	if movement.Type == "adjust" {
+		return errors.New("serialized product cannot be adjusted")
+	}
+	if len(serials) != movement.Quantity {
+		return errors.New("serial numbers must match quantity")
+	}
+
+	seen := map[string]bool{}
+	for _, serial := range serials {
+		if seen[serial] {
+			return errors.New("duplicate serial number")
+		}
+		seen[serial] = true
+
+		unit, err := s.RepoSerial.FindBySerialForUpdate(serial, tx)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		exists := err == nil
+
+		switch movement.Type {
+		case "in":
+			// Serial baru didaftarkan, serial lama yang sudah keluar boleh masuk kembali
+			if !exists {
+				unit = domain.SerialNumber{ProductID: product.ID, Serial: serial}
+			} else if unit.ProductID != product.ID {
+				return errors.New("serial number belongs to another product")
+			} else if unit.Status == "in_stock" {
+				return errors.New("serial number already in stock")
+			}
+			unit.Status = "in_stock"
+			unit.WarehouseID = movement.WarehouseID
+		case "out", "transfer":
+			if !exists || unit.ProductID != product.ID || unit.Status != "in_stock" || unit.WarehouseID != movement.WarehouseID {
+				return errors.New("serial number not in stock")
+			}
+			if movement.Type == "out" {
+				unit.Status = "out"
+			} else {
+				unit.WarehouseID = *movement.ToWarehouseID
+			}
+		}
+
+		unit, err = s.RepoSerial.Save(unit, tx)
+		if err != nil {
+			return err
+		}
+		movement.Serials = append(movement.Serials, domain.StockMovementSerial{SerialNumberID: unit.ID})
+	}
+	return nil
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
 	}
+	return t.Format("2006-01-02")
 }
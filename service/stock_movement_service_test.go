@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"inventory-management-api/config"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB membuka database MySQL khusus test dari env TEST_DB_DSN, misalnya
+// "root:secret@tcp(127.0.0.1:3306)/inventory_test?parseTime=true". Test dilewati jika env
+// tidak diisi karena penguncian baris hanya bisa dibuktikan di database sungguhan.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN not set")
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent), TranslateError: true})
+	if err != nil {
+		t.Fatalf("connect database: %v", err)
+	}
+	if err := config.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate database: %v", err)
+	}
+	return db
+}
+
+func newTestStockMovementService(db *gorm.DB) *stockMovementService {
+	return NewStockMovementService(
+		repository.NewStockMovementRepository(db),
+		repository.NewProductRepository(db),
+		repository.NewProductStockRepository(db),
+		repository.NewWarehouseRepository(db),
+		repository.NewStockLotRepository(db),
+		repository.NewSerialNumberRepository(db),
+		repository.NewReservationRepository(db),
+		repository.NewStockAlertRepository(db),
+		repository.NewCostLayerRepository(db),
+		repository.NewUnitRepository(db),
+		repository.NewKitRepository(db),
+		"average",
+		db,
+		validator.New(),
+	).(*stockMovementService)
+}
+
+// TestCreateConcurrentOutNeverNegative mengirim lebih banyak movement out paralel daripada
+// stok yang ada. Tepat sebanyak stok yang boleh berhasil dan saldo akhirnya harus 0.
+func TestCreateConcurrentOutNeverNegative(t *testing.T) {
+	db := testDB(t)
+	s := newTestStockMovementService(db)
+
+	const stock = 5
+	const requests = 20
+
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	user := domain.User{Name: "concurrency", Email: "concurrency-" + suffix + "@test.local", Role: "staff"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	category := domain.Category{Name: "concurrency-" + suffix}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	warehouse := domain.Warehouse{Name: "concurrency-" + suffix}
+	if err := db.Create(&warehouse).Error; err != nil {
+		t.Fatalf("create warehouse: %v", err)
+	}
+	product := domain.Product{Name: "concurrency-" + suffix, CategoryID: category.ID}
+	if err := db.Create(&product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+
+	// Stok awal masuk lewat movement seperti stok lainnya
+	if _, err := s.Create(user.ID, web.StockMovementCreateRequest{
+		ProductID: product.ID, WarehouseID: warehouse.ID, Type: "in", Quantity: stock,
+	}); err != nil {
+		t.Fatalf("opening stock: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	start := make(chan struct{})
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := s.Create(user.ID, web.StockMovementCreateRequest{
+				ProductID: product.ID, WarehouseID: warehouse.ID, Type: "out", Quantity: 1,
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				succeeded++
+			} else if err.Error() != "stock not enough" {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if succeeded != stock {
+		t.Errorf("succeeded = %d, want %d", succeeded, stock)
+	}
+
+	var final domain.Product
+	if err := db.First(&final, product.ID).Error; err != nil {
+		t.Fatalf("reload product: %v", err)
+	}
+	if final.Stock != 0 {
+		t.Errorf("product stock = %d, want 0", final.Stock)
+	}
+	var balance domain.ProductStock
+	if err := db.Where("product_id = ? AND warehouse_id = ?", product.ID, warehouse.ID).First(&balance).Error; err != nil {
+		t.Fatalf("reload warehouse stock: %v", err)
+	}
+	if balance.Stock != 0 {
+		t.Errorf("warehouse stock = %d, want 0", balance.Stock)
+	}
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StocktakeService interface {
+	FindAll() ([]web.StocktakeResponse, error)
+	FindById(id int) (web.StocktakeResponse, error)
+	Create(adminID int, req web.StocktakeCreateRequest) (web.StocktakeResponse, error)
+	SubmitCounts(id int, userID int, req web.StocktakeCountRequest) (web.StocktakeResponse, error)
+	Approve(id int, adminID int) (web.StocktakeResponse, error)
+	Cancel(id int) (web.StocktakeResponse, error)
+}
+
+type stocktakeService struct {
+	Repo            repository.StocktakeRepository
+	RepoProduct     repository.ProductRepository
+	RepoStock       repository.ProductStockRepository
+	RepoWarehouse   repository.WarehouseRepository
+	MovementService StockMovementService
+	DB              *gorm.DB
+	Validate        *validator.Validate
+}
+
+func NewStocktakeService(
+	repo repository.StocktakeRepository,
+	repoProduct repository.ProductRepository,
+	repoStock repository.ProductStockRepository,
+	repoWarehouse repository.WarehouseRepository,
+	movementService StockMovementService,
+	db *gorm.DB,
+	validate *validator.Validate,
+) StocktakeService {
+	return &stocktakeService{
+		Repo:            repo,
+		RepoProduct:     repoProduct,
+		RepoStock:       repoStock,
+		RepoWarehouse:   repoWarehouse,
+		MovementService: movementService,
+		DB:              db,
+		Validate:        validate,
+	}
+}
+
+func (s *stocktakeService) FindAll() ([]web.StocktakeResponse, error) {
+	stocktakes, err := s.Repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []web.StocktakeResponse
+	for _, st := range stocktakes {
+		responses = append(responses, toStocktakeResponse(st))
+	}
+	return responses, nil
+}
+
+func (s *stocktakeService) FindById(id int) (web.StocktakeResponse, error) {
+	st, err := s.Repo.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.StocktakeResponse{}, errors.New("stocktake not found")
+		}
+		return web.StocktakeResponse{}, err
+	}
+	return toStocktakeResponse(st), nil
+}
+
+// Create membuka sesi stock opname untuk daftar produk atau seluruh produk dalam satu kategori.
+// Saldo sistem saat sesi dibuka disimpan sebagai acuan awal selisih.
+func (s *stocktakeService) Create(adminID int, req web.StocktakeCreateRequest) (web.StocktakeResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.StocktakeResponse{}, errors.New("validation failed")
+	}
+
+	if _, err := s.RepoWarehouse.FindById(req.WarehouseID); err != nil {
+		return web.StocktakeResponse{}, errors.New("warehouse not found")
+	}
+
+	// Produk induk tidak punya stok sendiri, yang dihitung adalah variannya
+	variants, err := s.RepoProduct.FindAllVariants()
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	parents := map[int]bool{}
+	for _, v := range variants {
+		parents[*v.ParentID] = true
+	}
+
+	var products []domain.Product
+	if len(req.ProductIDs) > 0 {
+		seen := map[int]bool{}
+		for _, id := range req.ProductIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			p, err := s.RepoProduct.FindById(id)
+			if err != nil {
+				return web.StocktakeResponse{}, err
+			}
+			if p.Serialized {
+				return web.StocktakeResponse{}, errors.New("serialized product cannot be counted in stocktake")
+			}
+			if parents[p.ID] {
+				return web.StocktakeResponse{}, errors.New("parent product cannot be counted in stocktake")
+			}
+			products = append(products, p)
+		}
+	} else {
+		categoryProducts, err := s.RepoProduct.FindByCategoryId(req.CategoryID)
+		if err != nil {
+			return web.StocktakeResponse{}, err
+		}
+		// Produk serialized dihitung per unit, tidak ikut stock opname kuantitas
+		for _, p := range categoryProducts {
+			if !p.Serialized && !parents[p.ID] {
+				products = append(products, p)
+			}
+		}
+	}
+	if len(products) == 0 {
+		return web.StocktakeResponse{}, errors.New("no product to count")
+	}
+
+	// Urutkan berdasarkan ID agar penguncian baris produk saat approve selalu berurutan
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+
+	stocktake := domain.Stocktake{
+		WarehouseID: req.WarehouseID,
+		Status:      "open",
+		Note:        req.Note,
+		CreatedBy:   adminID,
+	}
+	if req.CategoryID != 0 {
+		stocktake.CategoryID = &req.CategoryID
+	}
+	for _, p := range products {
+		balance, err := s.RepoStock.FindByProductAndWarehouse(p.ID, req.WarehouseID)
+		if err != nil {
+			return web.StocktakeResponse{}, err
+		}
+		stocktake.Lines = append(stocktake.Lines, domain.StocktakeLine{
+			ProductID:      p.ID,
+			SystemQuantity: balance.Stock,
+		})
+	}
+
+	saved, err := s.Repo.Save(stocktake)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	return s.FindById(saved.ID)
+}
+
+// SubmitCounts menyimpan hasil hitung fisik. Boleh dikirim berulang selama sesi masih open,
+// hitungan terakhir yang dipakai. Saldo sistem saat dihitung ikut disimpan sebagai dasar selisih.
+func (s *stocktakeService) SubmitCounts(id int, userID int, req web.StocktakeCountRequest) (web.StocktakeResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.StocktakeResponse{}, errors.New("validation failed")
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		st, err := s.findOpenForUpdate(id, tx)
+		if err != nil {
+			return err
+		}
+
+		lines := map[int]domain.StocktakeLine{}
+		for _, line := range st.Lines {
+			lines[line.ProductID] = line
+		}
+
+		now := time.Now()
+		for _, count := range req.Counts {
+			line, ok := lines[count.ProductID]
+			if !ok {
+				return errors.New("product is not part of this stocktake")
+			}
+			balance, err := s.RepoStock.FindByProductAndWarehouse(count.ProductID, st.WarehouseID)
+			if err != nil {
+				return err
+			}
+			line.SystemQuantity = balance.Stock
+			line.CountedQuantity = count.CountedQuantity
+			line.CountedBy = &userID
+			line.CountedAt = &now
+			if err := s.Repo.UpdateLine(line, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+// Approve memposting selisih hasil hitung terhadap saldo sistem saat dihitung sebagai movement
+// adjust dengan reference "stocktake:<id>", semuanya dalam satu transaksi. Movement yang
+// diposting setelah barang dihitung tetap berlaku dan tidak ikut dikoreksi.
+func (s *stocktakeService) Approve(id int, adminID int) (web.StocktakeResponse, error) {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		st, err := s.findOpenForUpdate(id, tx)
+		if err != nil {
+			return err
+		}
+		for _, line := range st.Lines {
+			if line.CountedQuantity == nil {
+				return errors.New("stocktake has uncounted lines")
+			}
+		}
+
+		for _, line := range st.Lines {
+			variance := *line.CountedQuantity - line.SystemQuantity
+			if variance != 0 {
+				movement, err := s.MovementService.Post(tx, domain.StockMovement{
+					ProductID:   line.ProductID,
+					UserID:      adminID,
+					WarehouseID: st.WarehouseID,
+					Type:        "adjust",
+					Quantity:    variance,
+					Note:        "Stock opname",
+					Reference:   fmt.Sprintf("stocktake:%d", st.ID),
+				}, MovementOptions{})
+				if err != nil {
+					return err
+				}
+				line.MovementID = &movement.ID
+			}
+
+			if err := s.Repo.UpdateLine(line, tx); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		st.Status = "approved"
+		st.ApprovedBy = &adminID
+		st.ApprovedAt = &now
+		return s.Repo.UpdateStatus(st, tx)
+	})
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+func (s *stocktakeService) Cancel(id int) (web.StocktakeResponse, error) {
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		st, err := s.findOpenForUpdate(id, tx)
+		if err != nil {
+			return err
+		}
+		st.Status = "cancelled"
+		return s.Repo.UpdateStatus(st, tx)
+	})
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	return s.FindById(id)
+}
+
+func (s *stocktakeService) findOpenForUpdate(id int, tx *gorm.DB) (domain.Stocktake, error) {
+	st, err := s.Repo.FindByIdForUpdate(id, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Stocktake{}, errors.New("stocktake not found")
+		}
+		return domain.Stocktake{}, err
+	}
+	if st.Status != "open" {
+		return domain.Stocktake{}, errors.New("stocktake is not open")
+	}
+	return st, nil
+}
+
+func toStocktakeResponse(st domain.Stocktake) web.StocktakeResponse {
+	response := web.StocktakeResponse{
+		ID:          st.ID,
+		WarehouseID: st.WarehouseID,
+		CategoryID:  st.CategoryID,
+		Status:      st.Status,
+		Note:        st.Note,
+		CreatedBy:   st.CreatedBy,
+		ApprovedBy:  st.ApprovedBy,
+		CreatedAt:   st.CreatedAt,
+		ApprovedAt:  st.ApprovedAt,
+		Lines:       []web.StocktakeLineResponse{},
+	}
+	for _, line := range st.Lines {
+		lineResponse := web.StocktakeLineResponse{
+			ProductID:       line.ProductID,
+			Product:         line.Product.Name,
+			SystemQuantity:  line.SystemQuantity,
+			CountedQuantity: line.CountedQuantity,
+			MovementID:      line.MovementID,
+		}
+		if line.CountedQuantity != nil {
+			variance := *line.CountedQuantity - line.SystemQuantity
+			lineResponse.Variance = &variance
+		}
+		response.Lines = append(response.Lines, lineResponse)
+	}
+	return response
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type SupplierService interface {
+	FindAll() ([]web.SupplierResponse, error)
+	FindById(id int) (web.SupplierResponse, error)
+	Create(request web.SupplierCreateOrUpdateRequest) (web.SupplierResponse, error)
+	Update(id int, request web.SupplierCreateOrUpdateRequest) (web.SupplierResponse, error)
+	Delete(id int) error
+
+	FindProducts(supplierID int) ([]web.ProductSupplierResponse, error)
+	FindByProduct(productID int) ([]web.ProductSupplierResponse, error)
+	LinkProduct(productID, supplierID int, request web.ProductSupplierRequest) (web.ProductSupplierResponse, error)
+	UnlinkProduct(productID, supplierID int) error
+}
+
+type supplierService struct {
+	Repository  repository.SupplierRepository
+	RepoProduct repository.ProductRepository
+	Validate    *validator.Validate
+}
+
+func NewSupplierService(repo repository.SupplierRepository, repoProduct repository.ProductRepository, validate *validator.Validate) SupplierService {
+	return &supplierService{
+		Repository:  repo,
+		RepoProduct: repoProduct,
+		Validate:    validate,
+	}
+}
+
+func (s *supplierService) FindAll() ([]web.SupplierResponse, error) {
+	suppliers, err := s.Repository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []web.SupplierResponse
+	for _, sp := range suppliers {
+		responses = append(responses, toSupplierResponse(sp))
+	}
+	return responses, nil
+}
+
+func (s *supplierService) FindById(id int) (web.SupplierResponse, error) {
+	sp, err := s.Repository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.SupplierResponse{}, errors.New("supplier not found")
+		}
+		return web.SupplierResponse{}, err
+	}
+	return toSupplierResponse(sp), nil
+}
+
+func (s *supplierService) Create(req web.SupplierCreateOrUpdateRequest) (web.SupplierResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.SupplierResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+
+	saved, err := s.Repository.Save(domain.Supplier{
+		Name:          req.Name,
+		ContactPerson: req.ContactPerson,
+		Email:         req.Email,
+		Phone:         req.Phone,
+		Address:       req.Address,
+		LeadTimeDays:  req.LeadTimeDays,
+		PaymentTerms:  req.PaymentTerms,
+	})
+	if err != nil {
+		return web.SupplierResponse{}, err
+	}
+	return toSupplierResponse(saved), nil
+}
+
+func (s *supplierService) Update(id int, req web.SupplierCreateOrUpdateRequest) (web.SupplierResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.SupplierResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+
+	existing, err := s.Repository.FindById(id)
+	if err != nil {
+		return web.SupplierResponse{}, errors.New("supplier not found")
+	}
+
+	existing.Name = req.Name
+	existing.ContactPerson = req.ContactPerson
+	existing.Email = req.Email
+	existing.Phone = req.Phone
+	existing.Address = req.Address
+	existing.LeadTimeDays = req.LeadTimeDays
+	existing.PaymentTerms = req.PaymentTerms
+
+	updated, err := s.Repository.Update(existing)
+	if err != nil {
+		return web.SupplierResponse{}, err
+	}
+	return toSupplierResponse(updated), nil
+}
+
+func (s *supplierService) Delete(id int) error {
+	if _, err := s.Repository.FindById(id); err != nil {
+		return errors.New("supplier not found")
+	}
+
+	// Supplier yang masih terhubung dengan produk tidak boleh dihapus
+	inUse, err := s.Repository.IsInUse(id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("supplier is still in use")
+	}
+
+	return s.Repository.Delete(id)
+}
+
+func (s *supplierService) FindProducts(supplierID int) ([]web.ProductSupplierResponse, error) {
+	if _, err := s.Repository.FindById(supplierID); err != nil {
+		return nil, errors.New("supplier not found")
+	}
+
+	links, err := s.Repository.FindSupplierProducts(supplierID)
+	if err != nil {
+		return nil, err
+	}
+	return toProductSupplierResponses(links), nil
+}
+
+func (s *supplierService) FindByProduct(productID int) ([]web.ProductSupplierResponse, error) {
+	if _, err := s.RepoProduct.FindById(productID); err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	links, err := s.Repository.FindProductSuppliers(productID)
+	if err != nil {
+		return nil, err
+	}
+	return toProductSupplierResponses(links), nil
+}
+
+// LinkProduct membuat atau memperbarui hubungan produk dengan supplier
+func (s *supplierService) LinkProduct(productID, supplierID int, req web.ProductSupplierRequest) (web.ProductSupplierResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.ProductSupplierResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	if _, err := s.RepoProduct.FindById(productID); err != nil {
+		return web.ProductSupplierResponse{}, errors.New("product not found")
+	}
+	if _, err := s.Repository.FindById(supplierID); err != nil {
+		return web.ProductSupplierResponse{}, errors.New("supplier not found")
+	}
+
+	saved, err := s.Repository.SaveProductSupplier(domain.ProductSupplier{
+		ProductID:         productID,
+		SupplierID:        supplierID,
+		SupplierSKU:       req.SupplierSKU,
+		LastPurchasePrice: req.LastPurchasePrice,
+		Preferred:         req.Preferred,
+	})
+	if err != nil {
+		return web.ProductSupplierResponse{}, err
+	}
+	return toProductSupplierResponse(saved), nil
+}
+
+func (s *supplierService) UnlinkProduct(productID, supplierID int) error {
+	err := s.Repository.DeleteProductSupplier(productID, supplierID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("product supplier not found")
+	}
+	return err
+}
+
+func toSupplierResponse(sp domain.Supplier) web.SupplierResponse {
+	return web.SupplierResponse{
+		ID:            sp.ID,
+		Name:          sp.Name,
+		ContactPerson: sp.ContactPerson,
+		Email:         sp.Email,
+		Phone:         sp.Phone,
+		Address:       sp.Address,
+		LeadTimeDays:  sp.LeadTimeDays,
+		PaymentTerms:  sp.PaymentTerms,
+	}
+}
+
+func toProductSupplierResponse(link domain.ProductSupplier) web.ProductSupplierResponse {
+	return web.ProductSupplierResponse{
+		ProductID:         link.ProductID,
+		Product:           link.Product.Name,
+		SupplierID:        link.SupplierID,
+		Supplier:          link.Supplier.Name,
+		SupplierSKU:       link.SupplierSKU,
+		LastPurchasePrice: link.LastPurchasePrice,
+		Preferred:         link.Preferred,
+		LeadTimeDays:      link.Supplier.LeadTimeDays,
+	}
+}
+
+func toProductSupplierResponses(links []domain.ProductSupplier) []web.ProductSupplierResponse {
+	responses := []web.ProductSupplierResponse{}
+	for _, link := range links {
+		responses = append(responses, toProductSupplierResponse(link))
+	}
+	return responses
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UnitService interface {
+	FindAll() ([]web.UnitResponse, error)
+	Create(request web.UnitCreateOrUpdateRequest) (web.UnitResponse, error)
+	Update(id int, request web.UnitCreateOrUpdateRequest) (web.UnitResponse, error)
+	Delete(id int) error
+	FindProductUnits(productID int) (web.ProductUnitsResponse, error)
+	SetProductUnits(productID int, request web.ProductUnitsRequest) (web.ProductUnitsResponse, error)
+}
+
+type unitService struct {
+	Repo         repository.UnitRepository
+	RepoProduct  repository.ProductRepository
+	RepoMovement repository.StockMovementRepository
+	Validate     *validator.Validate
+}
+
+func NewUnitService(repo repository.UnitRepository, repoProduct repository.ProductRepository, repoMovement repository.StockMovementRepository, validate *validator.Validate) UnitService {
+	return &unitService{
+		Repo:         repo,
+		RepoProduct:  repoProduct,
+		RepoMovement: repoMovement,
+		Validate:     validate,
+	}
+}
+
+func (s *unitService) FindAll() ([]web.UnitResponse, error) {
+	units, err := s.Repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []web.UnitResponse{}
+	for _, u := range units {
+		responses = append(responses, toUnitResponse(u))
+	}
+	return responses, nil
+}
+
+func (s *unitService) Create(req web.UnitCreateOrUpdateRequest) (web.UnitResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.UnitResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	if _, err := s.Repo.FindByCode(req.Code); err == nil {
+		return web.UnitResponse{}, errors.New("unit code already exists")
+	}
+
+	saved, err := s.Repo.Save(domain.Unit{Code: req.Code, Name: req.Name})
+	if err != nil {
+		return web.UnitResponse{}, err
+	}
+	return toUnitResponse(saved), nil
+}
+
+func (s *unitService) Update(id int, req web.UnitCreateOrUpdateRequest) (web.UnitResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.UnitResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	if _, err := s.Repo.FindById(id); err != nil {
+		return web.UnitResponse{}, errors.New("unit not found")
+	}
+	if existing, err := s.Repo.FindByCode(req.Code); err == nil && existing.ID != id {
+		return web.UnitResponse{}, errors.New("unit code already exists")
+	}
+
+	updated, err := s.Repo.Update(domain.Unit{ID: id, Code: req.Code, Name: req.Name})
+	if err != nil {
+		return web.UnitResponse{}, err
+	}
+	return toUnitResponse(updated), nil
+}
+
+func (s *unitService) Delete(id int) error {
+	if _, err := s.Repo.FindById(id); err != nil {
+		return errors.New("unit not found")
+	}
+	inUse, err := s.Repo.IsInUse(id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("unit is still in use")
+	}
+	return s.Repo.Delete(id)
+}
+
+func (s *unitService) FindProductUnits(productID int) (web.ProductUnitsResponse, error) {
+	product, err := s.RepoProduct.FindById(productID)
+	if err != nil {
+		return web.ProductUnitsResponse{}, errors.New("product not found")
+	}
+	units, err := s.Repo.FindProductUnits(productID)
+	if err != nil {
+		return web.ProductUnitsResponse{}, err
+	}
+
+	response := web.ProductUnitsResponse{ProductID: productID, Units: []web.ProductUnitResponse{}}
+	if product.BaseUnitID != nil {
+		base, err := s.Repo.FindById(*product.BaseUnitID)
+		if err != nil {
+			return web.ProductUnitsResponse{}, err
+		}
+		response.BaseUnit = base.Code
+	}
+	for _, u := range units {
+		response.Units = append(response.Units, web.ProductUnitResponse{
+			Unit:   u.Unit.Code,
+			Name:   u.Unit.Name,
+			Factor: u.Factor,
+		})
+	}
+	return response, nil
+}
+
+// SetProductUnits mengganti satuan dasar dan satuan alternatif produk. Satuan dasar tidak
+// bisa diganti setelah produk punya stock movement, karena Quantity movement yang sudah
+// tercatat memakai satuan dasar lama.
+func (s *unitService) SetProductUnits(productID int, req web.ProductUnitsRequest) (web.ProductUnitsResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.ProductUnitsResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+	product, err := s.RepoProduct.FindById(productID)
+	if err != nil {
+		return web.ProductUnitsResponse{}, errors.New("product not found")
+	}
+
+	base, err := s.Repo.FindByCode(req.BaseUnit)
+	if err != nil {
+		return web.ProductUnitsResponse{}, err
+	}
+	if product.BaseUnitID != nil && *product.BaseUnitID != base.ID {
+		hasMovements, err := s.RepoMovement.ExistsByProduct(productID)
+		if err != nil {
+			return web.ProductUnitsResponse{}, err
+		}
+		if hasMovements {
+			return web.ProductUnitsResponse{}, errors.New("base unit cannot be changed after stock movements exist")
+		}
+	}
+
+	seen := map[int]bool{base.ID: true}
+	var units []domain.ProductUnit
+	for _, u := range req.Units {
+		unit, err := s.Repo.FindByCode(u.Unit)
+		if err != nil {
+			return web.ProductUnitsResponse{}, err
+		}
+		if seen[unit.ID] {
+			return web.ProductUnitsResponse{}, errors.New("duplicate unit for product")
+		}
+		seen[unit.ID] = true
+		units = append(units, domain.ProductUnit{ProductID: productID, UnitID: unit.ID, Factor: u.Factor})
+	}
+
+	if err := s.Repo.ReplaceProductUnits(productID, base.ID, units); err != nil {
+		return web.ProductUnitsResponse{}, err
+	}
+	return s.FindProductUnits(productID)
+}
+
+func toUnitResponse(u domain.Unit) web.UnitResponse {
+	return web.UnitResponse{ID: u.ID, Code: u.Code, Name: u.Name}
+}
+
+// resolveUnit mencari satuan code pada produk dan faktor konversinya ke satuan dasar
+func resolveUnit(repo repository.UnitRepository, product domain.Product, code string) (domain.Unit, int, error) {
+	unit, err := repo.FindByCode(code)
+	if err != nil {
+		return domain.Unit{}, 0, err
+	}
+	if product.BaseUnitID != nil && *product.BaseUnitID == unit.ID {
+		return unit, 1, nil
+	}
+
+	units, err := repo.FindProductUnits(product.ID)
+	if err != nil {
+		return domain.Unit{}, 0, err
+	}
+	for _, u := range units {
+		if u.UnitID == unit.ID {
+			return unit, u.Factor, nil
+		}
+	}
+	return domain.Unit{}, 0, errors.New("unit not configured for product")
+}
+
+// displayFactors mengembalikan faktor konversi satuan code per product_id untuk menampilkan
+// quantity dalam satuan tersebut. Produk yang tidak punya satuan itu tidak ada di map.
+func displayFactors(repo repository.UnitRepository, code string) (map[int]int, error) {
+	unit, err := repo.FindByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FactorsByUnit(unit.ID)
+}
+
+// inUnit mengubah quantity satuan dasar ke satuan dengan faktor factor
+func inUnit(quantity, factor int) float64 {
+	return math.Round(float64(quantity)/float64(factor)*10000) / 10000
+}
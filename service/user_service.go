@@ -17,6 +17,9 @@ type UserService interface {
 	Create(req web.UserCreateOrUpdateRequest) (web.UserResponse, error)
 	Update(id int, req web.UserCreateOrUpdateRequest) (web.UserResponse, error)
 	Delete(id int) error
+	FindTrashed() ([]web.UserResponse, error)
+	Restore(id int) (web.UserResponse, error)
+	Purge(id int) (web.PurgeResponse, error)
 }
 
 type userServiceImpl struct {
@@ -117,11 +120,56 @@ func (s *userServiceImpl) Delete(id int) error {
 	return s.UserRepo.Delete(user)
 }
 
+// FindTrashed mengambil user yang sudah dihapus dan masih bisa dipulihkan
+func (s *userServiceImpl) FindTrashed() ([]web.UserResponse, error) {
+	users, err := s.UserRepo.FindTrashed()
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []web.UserResponse
+	for _, user := range users {
+		responses = append(responses, toUserResponse(&user))
+	}
+	return responses, nil
+}
+
+func (s *userServiceImpl) Restore(id int) (web.UserResponse, error) {
+	if _, err := s.UserRepo.FindTrashedByID(id); err != nil {
+		return web.UserResponse{}, errors.New("user not found")
+	}
+	if err := s.UserRepo.Restore(id); err != nil {
+		return web.UserResponse{}, err
+	}
+	return s.FindByID(id)
+}
+
+// Purge menghapus permanen user yang sudah ada di trash. Ditolak selama masih ada transaksi
+// atau dokumen yang dicatat atas nama user tersebut.
+func (s *userServiceImpl) Purge(id int) (web.PurgeResponse, error) {
+	if _, err := s.UserRepo.FindTrashedByID(id); err != nil {
+		return web.PurgeResponse{}, errors.New("user not found")
+	}
+	references, err := s.UserRepo.CountReferences(id)
+	if err != nil {
+		return web.PurgeResponse{}, err
+	}
+	response := web.PurgeResponse{ID: id, References: references}
+	if len(references) > 0 {
+		return response, errors.New("user is still referenced")
+	}
+	return response, s.UserRepo.Purge(id)
+}
+
 func toUserResponse(user *domain.User) web.UserResponse {
-	return web.UserResponse{
+	response := web.UserResponse{
 		ID:    user.ID,
 		Name:  user.Name,
 		Email: user.Email,
 		Role:  user.Role,
 	}
+	if user.DeletedAt.Valid {
+		response.DeletedAt = &user.DeletedAt.Time
+	}
+	return response
 }
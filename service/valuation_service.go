@@ -0,0 +1,105 @@
+package service
+
+import (
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+)
+
+type ValuationService interface {
+	Valuation(asOf string, filters map[string]interface{}) (web.StockValuationResponse, error)
+}
+
+type valuationService struct {
+	RepoMovement  repository.StockMovementRepository
+	RepoProduct   repository.ProductRepository
+	RepoCategory  repository.CategoryRepository
+	CostingMethod string
+}
+
+func NewValuationService(repoMovement repository.StockMovementRepository, repoProduct repository.ProductRepository, repoCategory repository.CategoryRepository, costingMethod string) ValuationService {
+	return &valuationService{
+		RepoMovement:  repoMovement,
+		RepoProduct:   repoProduct,
+		RepoCategory:  repoCategory,
+		CostingMethod: costingMethod,
+	}
+}
+
+// Valuation menghitung quantity, harga pokok per unit, dan nilai persediaan per produk dan
+// per kategori pada akhir hari asOf (YYYY-MM-DD, default hari ini). Nilai diambil dari
+// TotalCost movement, sehingga mengikuti metode costing yang berlaku saat movement diposting.
+// Stok yang diisi langsung pada produk tanpa movement tidak ikut dinilai.
+func (s *valuationService) Valuation(asOf string, filters map[string]interface{}) (web.StockValuationResponse, error) {
+	start, err := parseAsOfDate(asOf)
+	if err != nil {
+		return web.StockValuationResponse{}, err
+	}
+
+	rows, err := s.RepoMovement.SumValuation(start.AddDate(0, 0, 1))
+	if err != nil {
+		return web.StockValuationResponse{}, err
+	}
+	products, err := s.RepoProduct.FindAll(filters)
+	if err != nil {
+		return web.StockValuationResponse{}, err
+	}
+	categories, err := s.RepoCategory.FindAll()
+	if err != nil {
+		return web.StockValuationResponse{}, err
+	}
+
+	balances := map[int]repository.StockValuation{}
+	for _, row := range rows {
+		balances[row.ProductID] = row
+	}
+	// Nama kategori ditampilkan lengkap dengan induknya, misalnya "Elektronik > Audio"
+	categoryNames := map[int]string{}
+	for _, c := range categories {
+		categoryNames[c.ID] = toCategoryResponse(c, categories).FullName
+	}
+
+	response := web.StockValuationResponse{
+		AsOf:          start.Format("2006-01-02"),
+		CostingMethod: s.CostingMethod,
+		Categories:    []web.CategoryValuationResponse{},
+		Products:      []web.ProductValuationResponse{},
+	}
+	categoryIndex := map[int]int{}
+	for _, p := range products {
+		balance, ok := balances[p.ID]
+		if !ok || (balance.Quantity == 0 && roundMoney(balance.Value) == 0) {
+			continue
+		}
+
+		value := roundMoney(balance.Value)
+		unitCost := 0.0
+		if balance.Quantity != 0 {
+			unitCost = roundUnitCost(balance.Value / float64(balance.Quantity))
+		}
+		response.Products = append(response.Products, web.ProductValuationResponse{
+			ProductID:  p.ID,
+			Name:       p.Name,
+			CategoryID: p.CategoryID,
+			Category:   categoryNames[p.CategoryID],
+			Quantity:   balance.Quantity,
+			UnitCost:   unitCost,
+			TotalValue: value,
+		})
+
+		i, ok := categoryIndex[p.CategoryID]
+		if !ok {
+			i = len(response.Categories)
+			categoryIndex[p.CategoryID] = i
+			response.Categories = append(response.Categories, web.CategoryValuationResponse{
+				CategoryID: p.CategoryID,
+				Category:   categoryNames[p.CategoryID],
+			})
+		}
+		response.Categories[i].Quantity += balance.Quantity
+		response.Categories[i].TotalValue = roundMoney(response.Categories[i].TotalValue + value)
+
+		response.TotalQuantity += balance.Quantity
+		response.TotalValue = roundMoney(response.TotalValue + value)
+	}
+	return response, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management-api/model/domain"
+	"inventory-management-api/model/web"
+	"inventory-management-api/repository"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type WarehouseService interface {
+	FindAll() ([]web.WarehouseResponse, error)
+	FindById(id int) (web.WarehouseResponse, error)
+	Create(request web.WarehouseCreateOrUpdateRequest) (web.WarehouseResponse, error)
+	Update(id int, request web.WarehouseCreateOrUpdateRequest) (web.WarehouseResponse, error)
+	Delete(id int) error
+}
+
+type warehouseService struct {
+	Repository repository.WarehouseRepository
+	Validate   *validator.Validate
+}
+
+func NewWarehouseService(repo repository.WarehouseRepository, validate *validator.Validate) WarehouseService {
+	return &warehouseService{
+		Repository: repo,
+		Validate:   validate,
+	}
+}
+
+func (s *warehouseService) FindAll() ([]web.WarehouseResponse, error) {
+	warehouses, err := s.Repository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []web.WarehouseResponse
+	for _, w := range warehouses {
+		responses = append(responses, toWarehouseResponse(w))
+	}
+	return responses, nil
+}
+
+func (s *warehouseService) FindById(id int) (web.WarehouseResponse, error) {
+	w, err := s.Repository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return web.WarehouseResponse{}, errors.New("warehouse not found")
+		}
+		return web.WarehouseResponse{}, err
+	}
+	return toWarehouseResponse(w), nil
+}
+
+func (s *warehouseService) Create(req web.WarehouseCreateOrUpdateRequest) (web.WarehouseResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.WarehouseResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+
+	warehouse := domain.Warehouse{
+		Name:    req.Name,
+		Address: req.Address,
+	}
+	saved, err := s.Repository.Save(warehouse)
+	if err != nil {
+		return web.WarehouseResponse{}, err
+	}
+	return toWarehouseResponse(saved), nil
+}
+
+func (s *warehouseService) Update(id int, req web.WarehouseCreateOrUpdateRequest) (web.WarehouseResponse, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return web.WarehouseResponse{}, fmt.Errorf("validation error: %w", err)
+	}
+
+	existing, err := s.Repository.FindById(id)
+	if err != nil {
+		return web.WarehouseResponse{}, errors.New("warehouse not found")
+	}
+
+	existing.Name = req.Name
+	existing.Address = req.Address
+
+	updated, err := s.Repository.Update(existing)
+	if err != nil {
+		return web.WarehouseResponse{}, err
+	}
+	return toWarehouseResponse(updated), nil
+}
+
+func (s *warehouseService) Delete(id int) error {
+	if _, err := s.Repository.FindById(id); err != nil {
+		return errors.New("warehouse not found")
+	}
+
+	// Gudang yang masih punya stok, riwayat movement, order, reservasi, atau stock opname tidak boleh dihapus
+	inUse, err := s.Repository.IsInUse(id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("warehouse is still in use")
+	}
+
+	return s.Repository.Delete(id)
+}
+
+func toWarehouseResponse(w domain.Warehouse) web.WarehouseResponse {
+	return web.WarehouseResponse{
+		ID:      w.ID,
+		Name:    w.Name,
+		Address: w.Address,
+	}
+}
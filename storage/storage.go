@@ -0,0 +1,13 @@
+package storage
+
+import "io"
+
+// Storage menyimpan berkas lampiran (gambar dan dokumen produk). Key adalah path relatif
+// berkas, misalnya "products/12/3f0c9a1e.jpg". Implementasi lain (misalnya S3-compatible)
+// cukup memenuhi interface ini lalu dipasang di config.NewStorage.
+type Storage interface {
+	Put(key string, content io.Reader, contentType string) error
+	// Open membuka berkas key untuk dibaca. Pemanggil wajib menutupnya.
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}